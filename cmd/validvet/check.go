@@ -0,0 +1,500 @@
+package main
+
+import (
+	"go/ast"
+	"go/constant"
+	"go/token"
+	"go/types"
+	"slices"
+	"strings"
+)
+
+const (
+	validPath      = "github.com/bitcrshr/valid"
+	validatorsPath = "github.com/bitcrshr/valid/validators"
+)
+
+type diagnostic struct {
+	pos     token.Pos
+	message string
+}
+
+type checker struct {
+	info  *types.Info
+	qual  types.Qualifier
+	diags []diagnostic
+}
+
+// check reports misuses of valid in files, which make up pkg.
+func check(files []*ast.File, info *types.Info, pkg *types.Package) []diagnostic {
+	c := &checker{info: info, qual: types.RelativeTo(pkg)}
+
+	for _, f := range files {
+		// Chains of rules are checked from their last call, so calls that
+		// are the receiver of another rule are skipped.
+		inner := map[*ast.CallExpr]bool{}
+		ast.Inspect(f, func(n ast.Node) bool {
+			if call, ok := n.(*ast.CallExpr); ok {
+				if l, ok := c.link(call); ok && l.recv != nil {
+					inner[l.recv] = true
+				}
+			}
+
+			return true
+		})
+
+		ast.Inspect(f, func(n ast.Node) bool {
+			call, ok := n.(*ast.CallExpr)
+			if !ok {
+				return true
+			}
+
+			c.checkShape(call)
+			if !inner[call] {
+				c.checkChain(call)
+			}
+
+			return true
+		})
+	}
+
+	slices.SortFunc(c.diags, func(a, b diagnostic) int { return int(a.pos - b.pos) })
+
+	return c.diags
+}
+
+func (c *checker) report(pos token.Pos, message string) {
+	c.diags = append(c.diags, diagnostic{pos: pos, message: message})
+}
+
+func (c *checker) typeString(t types.Type) string {
+	return types.TypeString(t, c.qual)
+}
+
+// checkShape checks the StructShape literal passed to a struct validator
+// constructor against the struct type the validator is for.
+func (c *checker) checkShape(call *ast.CallExpr) {
+	id := funcIdent(call.Fun)
+	if id == nil || len(call.Args) == 0 {
+		return
+	}
+
+	fn, ok := c.info.Uses[id].(*types.Func)
+	if !ok || fn.Pkg() == nil {
+		return
+	}
+
+	switch fn.Pkg().Path() + "." + fn.Name() {
+	case validPath + ".Struct", validPath + ".MustStruct",
+		validatorsPath + ".NewStructValidator", validatorsPath + ".MustStructValidator":
+	default:
+		return
+	}
+
+	inst, ok := c.info.Instances[id]
+	if !ok || inst.TypeArgs.Len() != 1 {
+		return
+	}
+
+	t := inst.TypeArgs.At(0)
+	if _, ok := t.Underlying().(*types.Struct); !ok {
+		return
+	}
+
+	lit, ok := ast.Unparen(call.Args[0]).(*ast.CompositeLit)
+	if !ok {
+		return
+	}
+
+	for _, elt := range lit.Elts {
+		kv, ok := elt.(*ast.KeyValueExpr)
+		if !ok {
+			continue
+		}
+
+		key := c.info.Types[kv.Key].Value
+		if key == nil || key.Kind() != constant.String {
+			continue
+		}
+
+		name := constant.StringVal(key)
+
+		ft, msg := c.fieldType(t, name)
+		if msg != "" {
+			c.report(kv.Key.Pos(), msg)
+			continue
+		}
+
+		vt := validatedType(c.info.Types[kv.Value].Type)
+		if vt != nil && !accepts(vt, ft) {
+			c.report(kv.Value.Pos(), "validator for "+c.typeString(t)+"."+name+" validates "+c.typeString(vt)+", not "+c.typeString(ft))
+		}
+	}
+}
+
+// fieldType resolves key, a field name or dotted path of field names as in
+// validators.StructShape, in t. If it cannot be resolved, it returns a
+// message explaining why instead.
+func (c *checker) fieldType(t types.Type, key string) (types.Type, string) {
+	for name := range strings.SplitSeq(key, ".") {
+		for {
+			p, ok := t.Underlying().(*types.Pointer)
+			if !ok {
+				break
+			}
+
+			t = p.Elem()
+		}
+
+		if _, ok := t.Underlying().(*types.Struct); !ok {
+			return nil, c.typeString(t) + " is not a struct"
+		}
+
+		obj, _, _ := types.LookupFieldOrMethod(t, false, nil, name)
+		field, ok := obj.(*types.Var)
+		if !ok || !field.IsField() || !field.Exported() {
+			return nil, c.typeString(t) + " has no exported field " + name
+		}
+
+		t = field.Type()
+	}
+
+	return t, ""
+}
+
+// funcIdent returns the identifier naming the function called by fun, which
+// may be qualified by a package and instantiated explicitly.
+func funcIdent(fun ast.Expr) *ast.Ident {
+	switch f := ast.Unparen(fun).(type) {
+	case *ast.IndexExpr:
+		return funcIdent(f.X)
+	case *ast.IndexListExpr:
+		return funcIdent(f.X)
+	case *ast.SelectorExpr:
+		return f.Sel
+	case *ast.Ident:
+		return f
+	}
+
+	return nil
+}
+
+// validatedType returns the parameter type of the Validate method of t, or
+// nil if t has none.
+func validatedType(t types.Type) types.Type {
+	if t == nil {
+		return nil
+	}
+
+	obj, _, _ := types.LookupFieldOrMethod(t, true, nil, "Validate")
+	fn, ok := obj.(*types.Func)
+	if !ok {
+		return nil
+	}
+
+	sig := fn.Type().(*types.Signature)
+	if sig.Params().Len() != 1 {
+		return nil
+	}
+
+	return sig.Params().At(0).Type()
+}
+
+// accepts mirrors the check validators.NewStructValidator does at runtime.
+func accepts(vt, t types.Type) bool {
+	if types.Identical(vt, t) {
+		return true
+	}
+
+	if iface, ok := vt.Underlying().(*types.Interface); ok {
+		return types.Implements(t, iface)
+	}
+
+	if iface, ok := t.Underlying().(*types.Interface); ok {
+		return types.Implements(vt, iface)
+	}
+
+	return false
+}
+
+// link is a call of a rule method in a chain like
+// valid.String().MinLen(3).MaxLen(5).
+type link struct {
+	call *ast.CallExpr
+	// pos is the position of the method name.
+	pos  token.Pos
+	name string
+	// kind is the name of the validator interface the method belongs to,
+	// e.g. "StringValidator".
+	kind string
+	// recv is the call the method is called on, if it is part of the chain.
+	recv *ast.CallExpr
+	// integer is set for number validators of integer types.
+	integer bool
+}
+
+func (c *checker) link(call *ast.CallExpr) (link, bool) {
+	sel, ok := ast.Unparen(call.Fun).(*ast.SelectorExpr)
+	if !ok {
+		return link{}, false
+	}
+
+	s, ok := c.info.Selections[sel]
+	if !ok || s.Kind() != types.MethodVal || s.Obj().Pkg() == nil || s.Obj().Pkg().Path() != validatorsPath {
+		return link{}, false
+	}
+
+	named, ok := types.Unalias(s.Recv()).(*types.Named)
+	if !ok {
+		return link{}, false
+	}
+
+	l := link{call: call, pos: sel.Sel.Pos(), name: sel.Sel.Name, kind: named.Obj().Name()}
+	if l.kind == "NumberValidator" && named.TypeArgs().Len() == 1 {
+		if b, ok := named.TypeArgs().At(0).Underlying().(*types.Basic); ok {
+			l.integer = b.Info()&types.IsInteger != 0
+		}
+	}
+
+	if recv, ok := ast.Unparen(sel.X).(*ast.CallExpr); ok {
+		if _, ok := c.link(recv); ok {
+			l.recv = recv
+		}
+	}
+
+	return l, true
+}
+
+// checkChain reports rules in the chain ending in call that contradict
+// earlier rules of the same validator, so that no value can pass.
+func (c *checker) checkChain(call *ast.CallExpr) {
+	var chain []link
+	for l, ok := c.link(call); ok; l, ok = c.link(l.recv) {
+		chain = append(chain, l)
+		if l.recv == nil {
+			break
+		}
+	}
+
+	slices.Reverse(chain)
+
+	// Rules in different validation groups may never be checked together.
+	for _, l := range chain {
+		if l.name == "Groups" {
+			return
+		}
+	}
+
+	// Warnings do not make values invalid, so they cannot contradict.
+	var rules []link
+	for i, l := range chain {
+		if l.name != "AsWarning" && (i+1 == len(chain) || chain[i+1].name != "AsWarning") {
+			rules = append(rules, l)
+		}
+	}
+	chain = rules
+
+	// A chain may move on to another validator, e.g. through ElemValidator,
+	// in which case the constraints start over.
+	var lens *lenBounds
+	var nums *numBounds
+	kind := ""
+	for _, l := range chain {
+		if l.kind != kind {
+			kind, lens, nums = l.kind, &lenBounds{hi: -1}, &numBounds{}
+		}
+
+		var msg string
+		switch l.kind {
+		case "StringValidator", "SliceValidator", "MapValidator":
+			msg = lens.add(c, l)
+		case "NumberValidator":
+			msg = nums.add(c, l)
+		}
+
+		if msg != "" {
+			c.report(l.pos, msg)
+			return
+		}
+	}
+}
+
+func (c *checker) describe(l link) string {
+	args := make([]string, len(l.call.Args))
+	for i, a := range l.call.Args {
+		args[i] = types.ExprString(a)
+	}
+
+	return l.name + "(" + strings.Join(args, ", ") + ")"
+}
+
+func (c *checker) constArg(l link, i int) constant.Value {
+	if i >= len(l.call.Args) {
+		return nil
+	}
+
+	return c.info.Types[l.call.Args[i]].Value
+}
+
+// lenBounds tracks the lengths allowed by the rules seen so far. A negative
+// hi means the length is unbounded.
+type lenBounds struct {
+	lo, hi         int64
+	loRule, hiRule string
+}
+
+func (b *lenBounds) add(c *checker, l link) string {
+	var lo, hi int64 = 0, -1
+	switch l.name {
+	case "Empty":
+		hi = 0
+	case "NotEmpty":
+		lo = 1
+	case "Len", "MinLen", "MaxLen":
+		v := c.constArg(l, 0)
+		if v == nil {
+			return ""
+		}
+
+		n, ok := constant.Int64Val(constant.ToInt(v))
+		if !ok {
+			return ""
+		}
+
+		switch l.name {
+		case "Len":
+			lo, hi = n, n
+		case "MinLen":
+			lo = n
+		case "MaxLen":
+			hi = n
+		}
+	default:
+		return ""
+	}
+
+	rule := c.describe(l)
+	if lo > b.lo {
+		b.lo, b.loRule = lo, rule
+	}
+
+	if hi >= 0 && (b.hi < 0 || hi < b.hi) {
+		b.hi, b.hiRule = hi, rule
+	}
+
+	if b.hi >= 0 && b.lo > b.hi {
+		other := b.loRule
+		if other == rule {
+			other = b.hiRule
+		}
+
+		return rule + " contradicts " + other + ", so no value can pass"
+	}
+
+	return ""
+}
+
+// numBounds tracks the numbers allowed by the rules seen so far.
+type numBounds struct {
+	lo, hi         *bound
+	excluded       []constant.Value
+	exclusionRules []string
+}
+
+type bound struct {
+	v      constant.Value
+	strict bool
+	rule   string
+}
+
+func (b *numBounds) add(c *checker, l link) string {
+	zero := constant.MakeInt64(0)
+	rule := c.describe(l)
+
+	var lo, hi *bound
+	switch l.name {
+	case "Positive":
+		lo = &bound{v: zero}
+	case "Negative":
+		hi = &bound{v: zero}
+	case "Zero":
+		lo, hi = &bound{v: zero}, &bound{v: zero}
+	case "NonZero":
+		b.excluded = append(b.excluded, zero)
+		b.exclusionRules = append(b.exclusionRules, rule)
+	case "GT", "GTE", "LT", "LTE", "EqualTo", "NotEqualTo":
+		v := c.constArg(l, 0)
+		if v == nil {
+			return ""
+		}
+
+		switch l.name {
+		case "GT":
+			lo = &bound{v: v, strict: true}
+		case "GTE":
+			lo = &bound{v: v}
+		case "LT":
+			hi = &bound{v: v, strict: true}
+		case "LTE":
+			hi = &bound{v: v}
+		case "EqualTo":
+			lo, hi = &bound{v: v}, &bound{v: v}
+		case "NotEqualTo":
+			b.excluded = append(b.excluded, v)
+			b.exclusionRules = append(b.exclusionRules, rule)
+		}
+	default:
+		return ""
+	}
+
+	if lo != nil {
+		lo.rule = rule
+		if l.integer && lo.strict {
+			lo.v, lo.strict = constant.BinaryOp(constant.ToInt(lo.v), token.ADD, constant.MakeInt64(1)), false
+		}
+
+		if b.lo == nil || constant.Compare(lo.v, token.GTR, b.lo.v) || (lo.strict && !b.lo.strict && constant.Compare(lo.v, token.EQL, b.lo.v)) {
+			b.lo = lo
+		}
+	}
+
+	if hi != nil {
+		hi.rule = rule
+		if l.integer && hi.strict {
+			hi.v, hi.strict = constant.BinaryOp(constant.ToInt(hi.v), token.SUB, constant.MakeInt64(1)), false
+		}
+
+		if b.hi == nil || constant.Compare(hi.v, token.LSS, b.hi.v) || (hi.strict && !b.hi.strict && constant.Compare(hi.v, token.EQL, b.hi.v)) {
+			b.hi = hi
+		}
+	}
+
+	if b.lo == nil || b.hi == nil {
+		return ""
+	}
+
+	other := func(rules ...string) string {
+		for _, r := range rules {
+			if r != rule {
+				return r
+			}
+		}
+
+		return rule
+	}
+
+	if constant.Compare(b.lo.v, token.GTR, b.hi.v) ||
+		(constant.Compare(b.lo.v, token.EQL, b.hi.v) && (b.lo.strict || b.hi.strict)) {
+		return rule + " contradicts " + other(b.lo.rule, b.hi.rule) + ", so no value can pass"
+	}
+
+	if constant.Compare(b.lo.v, token.EQL, b.hi.v) {
+		for i, v := range b.excluded {
+			if constant.Compare(v, token.EQL, b.lo.v) {
+				return rule + " contradicts " + other(b.exclusionRules[i], b.lo.rule, b.hi.rule) + ", so no value can pass"
+			}
+		}
+	}
+
+	return ""
+}
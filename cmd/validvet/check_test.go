@@ -0,0 +1,101 @@
+package main
+
+import (
+	"go/parser"
+	"go/token"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+// TestRun checks the diagnostics for the packages in testdata against their
+// want comments, which hold a quoted regular expression the diagnostic for
+// their line must match.
+func TestRun(t *testing.T) {
+	dirs, err := filepath.Glob("testdata/*")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, dir := range dirs {
+		t.Run(filepath.Base(dir), func(t *testing.T) {
+			want := wants(t, dir)
+
+			diags, err := run([]string{"./" + dir})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			for _, d := range diags {
+				pos, msg, _ := strings.Cut(d, ": ")
+				file, line := splitPos(t, pos)
+				key := file + ":" + line
+
+				re, ok := want[key]
+				if !ok {
+					t.Errorf("unexpected diagnostic %s", d)
+					continue
+				}
+
+				if !re.MatchString(msg) {
+					t.Errorf("%s: diagnostic %q does not match %q", key, msg, re)
+				}
+
+				delete(want, key)
+			}
+
+			for key, re := range want {
+				t.Errorf("%s: missing diagnostic matching %q", key, re)
+			}
+		})
+	}
+}
+
+var wantComment = regexp.MustCompile("^// want (`[^`]*`)$")
+
+func wants(t *testing.T, dir string) map[string]*regexp.Regexp {
+	t.Helper()
+
+	fset := token.NewFileSet()
+	pkgs, err := parser.ParseDir(fset, dir, nil, parser.ParseComments)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := map[string]*regexp.Regexp{}
+	for _, pkg := range pkgs {
+		for _, f := range pkg.Files {
+			for _, cg := range f.Comments {
+				for _, c := range cg.List {
+					m := wantComment.FindStringSubmatch(c.Text)
+					if m == nil {
+						continue
+					}
+
+					expr, err := strconv.Unquote(m[1])
+					if err != nil {
+						t.Fatal(err)
+					}
+
+					pos := fset.Position(c.Pos())
+					want[filepath.Base(pos.Filename)+":"+strconv.Itoa(pos.Line)] = regexp.MustCompile(expr)
+				}
+			}
+		}
+	}
+
+	return want
+}
+
+func splitPos(t *testing.T, pos string) (file, line string) {
+	t.Helper()
+
+	parts := strings.Split(pos, ":")
+	if len(parts) < 3 {
+		t.Fatalf("malformed position %q", pos)
+	}
+
+	return filepath.Base(parts[0]), parts[1]
+}
@@ -0,0 +1,68 @@
+// Validvet reports mistakes in the use of valid that can be found without
+// running the code:
+//
+//   - StructShape literals passed to valid.Struct, valid.MustStruct,
+//     validators.NewStructValidator or validators.MustStructValidator with keys
+//     that are not exported fields of the struct, or with validators that do
+//     not validate the type of their field.
+//   - Chains of rules that no value can satisfy, like MinLen(10).MaxLen(5),
+//     Len(3).Len(4) or GT(10).LT(5).
+//
+// Usage:
+//
+//	validvet [packages]
+//
+// Packages are given as for go list and default to ./... Validvet exits with
+// status 1 if it reports any problems, and 2 if the packages cannot be loaded.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+)
+
+func main() {
+	flag.Usage = func() {
+		fmt.Fprintln(flag.CommandLine.Output(), "usage: validvet [packages]")
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+
+	patterns := flag.Args()
+	if len(patterns) == 0 {
+		patterns = []string{"./..."}
+	}
+
+	diags, err := run(patterns)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "validvet:", err)
+		os.Exit(2)
+	}
+
+	for _, d := range diags {
+		fmt.Fprintln(os.Stderr, d)
+	}
+
+	if len(diags) > 0 {
+		os.Exit(1)
+	}
+}
+
+// run checks the packages matching patterns and returns the problems found,
+// formatted as "file:line:col: message".
+func run(patterns []string) ([]string, error) {
+	pkgs, err := load(patterns)
+	if err != nil {
+		return nil, err
+	}
+
+	var out []string
+	for _, p := range pkgs {
+		for _, d := range check(p.files, p.info, p.types) {
+			out = append(out, fmt.Sprintf("%s: %s", p.fset.Position(d.pos), d.message))
+		}
+	}
+
+	return out, nil
+}
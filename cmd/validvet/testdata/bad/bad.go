@@ -0,0 +1,60 @@
+package bad
+
+import (
+	"github.com/bitcrshr/valid"
+	"github.com/bitcrshr/valid/validators"
+)
+
+type Address struct {
+	City string
+}
+
+type User struct {
+	Name    string
+	Age     int
+	Address *Address
+}
+
+var _ = valid.MustStruct[User](validators.StructShape{
+	"Nmae":           valid.String(), // want `User has no exported field Nmae`
+	"Age":            valid.String(), // want `validator for User.Age validates string, not int`
+	"Address":        valid.Pointer(valid.MustStruct[Address](validators.StructShape{})),
+	"Address.City":   valid.String().NotEmpty(),
+	"Address.Zip":    valid.String(), // want `Address has no exported field Zip`
+	"Address.City.X": valid.String(), // want `string is not a struct`
+	"Name.Len":       valid.Int(),    // want `string is not a struct`
+})
+
+var _, _ = validators.NewStructValidator[User](validators.StructShape{
+	"Name": valid.String().NotEmpty(),
+	"Age":  valid.Int().GTE(0),
+	"Address": valid.Pointer(valid.MustStruct[Address](validators.StructShape{
+		"City": valid.Int(), // want `validator for Address.City validates int, not string`
+	})),
+})
+
+var (
+	_ = valid.String().MinLen(10).MaxLen(5) // want `MaxLen\(5\) contradicts MinLen\(10\), so no value can pass`
+	_ = valid.String().MinLen(2).MaxLen(5)
+	_ = valid.String().NotEmpty().Empty() // want `Empty\(\) contradicts NotEmpty\(\)`
+	_ = valid.String().Empty().Groups("create").NotEmpty().Groups("update")
+	_ = valid.String().MaxLen(5).MinLen(10).AsWarning()
+	_ = valid.String().MinLen(10).AsWarning().Len(3).Len(4) // want `Len\(4\) contradicts Len\(3\)`
+
+	_ = valid.Slice[[]string](valid.String()).Len(3).Len(4)               // want `Len\(4\) contradicts Len\(3\)`
+	_ = valid.Slice[[]string](valid.String()).Len(3).MaxLen(2).NotEmpty() // want `MaxLen\(2\) contradicts Len\(3\)`
+	_ = valid.Slice[[]string](valid.String()).Len(3).MinLen(1)
+	_ = valid.Slice[[]string](valid.String().MinLen(4).MaxLen(3)) // want `MaxLen\(3\) contradicts MinLen\(4\)`
+
+	_ = valid.Int().GT(10).LT(5) // want `LT\(5\) contradicts GT\(10\)`
+	_ = valid.Int().GT(4).LT(5)  // want `LT\(5\) contradicts GT\(4\)`
+	_ = valid.Float64().GT(4).LT(5)
+	_ = valid.Int().Zero().NonZero()                    // want `NonZero\(\) contradicts Zero\(\)`
+	_ = valid.Int().Positive().Negative().NotEqualTo(0) // want `NotEqualTo\(0\) contradicts`
+	_ = valid.Int().Positive().Negative()
+)
+
+func dynamic(n int) {
+	_ = valid.String().MinLen(n).MaxLen(5)
+	_ = valid.Pointer(valid.String()).ElemValidator().MinLen(3).MaxLen(2) // want `MaxLen\(2\) contradicts MinLen\(3\)`
+}
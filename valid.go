@@ -1,6 +1,8 @@
 package valid
 
 import (
+	"log/slog"
+
 	"github.com/bitcrshr/valid/validators"
 	"golang.org/x/exp/constraints"
 )
@@ -77,6 +79,27 @@ func Slice[S ~[]E, E any, V validators.Validator[E]](elemValidator V) validators
 	return validators.NewSliceValidator[S](elemValidator)
 }
 
-func Struct[T any](shape validators.StructShape) *validators.StructValidator[T] {
-	return validators.NewStructValidator[T](shape)
+func Struct[T any](shape validators.StructShape, opts ...validators.StructOption) (*validators.StructValidator[T], error) {
+	return validators.NewStructValidator[T](shape, opts...)
+}
+
+func MustStruct[T any](shape validators.StructShape, opts ...validators.StructOption) *validators.StructValidator[T] {
+	return validators.MustStructValidator[T](shape, opts...)
+}
+
+func Shadow[T any](active, candidate validators.Validator[T], sink validators.ShadowSink, opts ...validators.ShadowOption) *validators.ShadowValidator[T] {
+	return validators.NewShadowValidator(active, candidate, sink, opts...)
+}
+
+// LogAttrs returns compact attributes describing err for structured logging,
+// see validators.LogAttrs.
+func LogAttrs(err error) []slog.Attr {
+	return validators.LogAttrs(err)
+}
+
+// Compare returns the differences between old and new, two versions of a
+// validator, classified by whether they make valid values invalid, see
+// validators.Compare.
+func Compare(old, new validators.AnyValidator) validators.Changes {
+	return validators.Compare(validators.Describe(old), validators.Describe(new))
 }
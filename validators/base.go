@@ -1,22 +1,160 @@
 package validators
 
-import "fmt"
+import (
+	"reflect"
+	"slices"
+	"strings"
+	"sync/atomic"
+	"time"
+)
 
 type baseValidator[T any, Super Validator[T]] struct {
-	checks []func(T) error
-	super  Super
+	rules []rule[T]
+	// compiled are the rules as they are checked, with adjacent length
+	// checks fused, see fuse.
+	compiled []rule[T]
+	super    Super
+	// clone returns a copy of the concrete validator that uses the given
+	// base, so that builder methods can derive new validators without
+	// modifying the one they are called on.
+	clone  func(*baseValidator[T, Super]) Super
+	sealed atomic.Bool
+	// sensitive is set if errors must not show values, see Sensitive.
+	sensitive bool
+	// maxErrors is the limit set with MaxErrors, or 0.
+	maxErrors int
+	// tracked is set if a validator nested in v needs to know the path to
+	// the value it validates, see tracksPaths.
+	tracked bool
+	// sensitiveNested is set if a validator nested in v is sensitive, so
+	// that errors of v, which show nested values, are redacted as well.
+	sensitiveNested bool
 }
 
-func newBaseValidator[T any, Super Validator[T]](super Super) *baseValidator[T, Super] {
+func newBaseValidator[T any, Super Validator[T]](super Super, clone func(*baseValidator[T, Super]) Super) *baseValidator[T, Super] {
 	return &baseValidator[T, Super]{
-		checks: make([]func(T) error, 0),
-		super:  super,
+		rules: make([]rule[T], 0),
+		super: super,
+		clone: clone,
 	}
 }
 
+// Validate checks value against the rules of v that are in no validation
+// group.
 func (v *baseValidator[T, Super]) Validate(value T) error {
-	for _, check := range v.checks {
-		if err := check(value); err != nil {
+	return v.validateIn(v.evaluation(), value)
+}
+
+func (v *baseValidator[T, Super]) ValidateAny(value any) error {
+	return v.ValidateAnyIn(v.evaluation(), value)
+}
+
+// ValidateGroups checks value against the rules of v, and of the validators
+// nested in it, that are in no group or in one of groups.
+func (v *baseValidator[T, Super]) ValidateGroups(value T, groups ...string) error {
+	return v.validateIn(v.evaluation(groups...), value)
+}
+
+func (v *baseValidator[T, Super]) ValidateAnyGroups(value any, groups ...string) error {
+	return v.ValidateAnyIn(v.evaluation(groups...), value)
+}
+
+// evaluation returns the evaluation of a validation with v in groups, see
+// NewEvaluation, which tracks paths if a validator nested in v needs them.
+func (v *baseValidator[T, Super]) evaluation(groups ...string) *Evaluation {
+	ev := NewEvaluation(groups...)
+	if v.tracked {
+		return tracking(ev)
+	}
+
+	return ev
+}
+
+// Check validates value like ValidateGroups, and additionally checks the
+// rules marked with AsWarning, of v and of the validators nested in it. Their
+// violations are returned as warnings, which do not make value invalid.
+func (v *baseValidator[T, Super]) Check(value T, groups ...string) Result {
+	ev := &Evaluation{groups: groups, warn: true, observer: loadObserver()}
+	err := v.validateIn(ev, value)
+
+	return Result{Errors: AsErrors(err), Warnings: ev.warnings}
+}
+
+// validateIn checks the rules of v in order, stopping at the first error.
+// Violated warnings do not stop it.
+func (v *baseValidator[T, Super]) validateIn(ev *Evaluation, value T) error {
+	ev, done := v.limitIn(ev)
+	defer done()
+
+	var d *display
+	if ev != nil && ev.display != nil {
+		d, ev.display = ev.display, nil
+	}
+
+	if ev != nil && ev.trace != nil {
+		return v.explainIn(ev, value, d)
+	}
+
+	if ev != nil && ev.observer != nil {
+		return v.observeIn(ev, value, d)
+	}
+
+	err := v.checkRules(ev, value)
+	if d != nil {
+		// Boxing value allocates, so it is only done for conversions.
+		err = d.show(err, value)
+	}
+
+	return err
+}
+
+// observeIn is like validateIn, and notifies the observer of ev.
+func (v *baseValidator[T, Super]) observeIn(ev *Evaluation, value T, d *display) error {
+	outer := ev.validator
+	defer func() { ev.validator = outer }()
+
+	ev.validator = d.name(reflect.TypeFor[T]())
+
+	start := time.Now()
+	err := d.show(v.checkRules(ev, value), value)
+	ev.observer.Validated(ValidationEvent{Validator: ev.validator, Path: ev.pathTo(""), Duration: time.Since(start), Err: err})
+
+	return err
+}
+
+func (v *baseValidator[T, Super]) checkRules(ev *Evaluation, value T) error {
+	return checkRules(ev, v.compiled, value, v.showsSensitive())
+}
+
+// checkRules checks value against rules in order, stopping at the first error.
+// The parts of a fused rule are only checked if the fused rule fails.
+func checkRules[T any](ev *Evaluation, rules []rule[T], value T, sensitive bool) error {
+	for _, r := range rules {
+		if !ev.Checks(r.Rule) {
+			ev.traceRule(r.Rule, RuleSkipped, nil)
+			continue
+		}
+
+		if r.parts != nil {
+			if r.check(value) == nil {
+				continue
+			}
+
+			if err := checkRules(ev, r.parts, value, sensitive); err != nil {
+				return err
+			}
+
+			continue
+		}
+
+		err := annotate(r.run(ev, value), r, value, sensitive)
+		if err != nil {
+			ev.traceRule(r.Rule, RuleFailed, err)
+		} else {
+			ev.traceRule(r.Rule, RulePassed, nil)
+		}
+
+		if err := ev.report(r.Rule, err, r.checkIn == nil); err != nil {
 			return err
 		}
 	}
@@ -24,16 +162,183 @@ func (v *baseValidator[T, Super]) Validate(value T) error {
 	return nil
 }
 
-func (v *baseValidator[T, Super]) ValidateAny(value any) error {
+// annotate returns err, the error of the rule r for value, with the params of
+// r and value attached if r is a leaf rule, i.e. does not validate nested
+// values. If value is sensitive, the errors of r itself are redacted, and
+// params, which may show the value, are left out.
+func annotate[T any](err error, r rule[T], value T, sensitive bool) error {
+	e, ok := err.(*Error)
+	switch {
+	case !ok || e.Value != nil:
+		return err
+	case sensitive && r.Name != "":
+		e = redactError(e, value)
+	case r.checkIn == nil && e.Path == "":
+		c := *e
+		c.Value = value
+		e = &c
+	default:
+		return err
+	}
+
+	if r.checkIn == nil && !sensitive {
+		e.Params = r.Params
+	}
+
+	return e
+}
+
+func (v *baseValidator[T, Super]) ValidateAnyIn(ev *Evaluation, value any) error {
 	t, ok := value.(T)
 	if !ok {
-		return fmt.Errorf("expected value of type %T, but found %T", t, value)
+		return newError(CodeType, "expected value of type %T, but found %T", t, value)
+	}
+
+	return v.validateIn(ev, t)
+}
+
+// Rules returns the rules that were added to the validator, in the order
+// they are checked.
+func (v *baseValidator[T, Super]) Rules() []Rule {
+	rules := make([]Rule, 0, len(v.rules))
+	for _, r := range v.rules {
+		if r.Name != "" {
+			rules = append(rules, r.Rule)
+		}
 	}
 
-	return v.Validate(t)
+	return rules
 }
 
 func (v *baseValidator[T, Super]) Satisfies(check func(T) error) Super {
-	v.checks = append(v.checks, check)
-	return v.super
+	return v.with(
+		Rule{Name: "satisfies"},
+		func(t T) error {
+			if err := check(t); err != nil {
+				return withCode("satisfies", err)
+			}
+
+			return nil
+		},
+	)
+}
+
+// Groups puts the rule added last into the given validation groups, e.g.
+// valid.String().Empty().Groups("create"). Rules in groups are only checked
+// by ValidateGroups when one of their groups is requested. It panics if no
+// rule was added yet.
+func (v *baseValidator[T, Super]) Groups(groups ...string) Super {
+	return v.modifyLast("Groups", func(r *Rule) {
+		r.Groups = append(slices.Clip(r.Groups), groups...)
+	})
+}
+
+// AsWarning marks the rule added last as a warning, e.g.
+// valid.String().MaxLen(20).AsWarning(). Warnings are only checked by Check,
+// which reports their violations separately from errors; Validate ignores
+// them. It panics if no rule was added yet.
+func (v *baseValidator[T, Super]) AsWarning() Super {
+	return v.modifyLast("AsWarning", func(r *Rule) {
+		r.Warning = true
+	})
+}
+
+// modifyLast returns a new validator with the rule added last changed by
+// modify. It panics, naming method, if no rule was added yet.
+func (v *baseValidator[T, Super]) modifyLast(method string, modify func(*Rule)) Super {
+	v.mustNotBeSealed(strings.ToLower(method[:1]) + method[1:])
+
+	last := len(v.rules) - 1
+	if last < 0 || v.rules[last].Name == "" {
+		panic("validators: " + method + " must follow a rule")
+	}
+
+	rules := slices.Clone(v.rules)
+	modify(&rules[last].Rule)
+
+	return v.derive(rules)
+}
+
+// with returns a new validator with the rules of v followed by r. v itself is
+// left unchanged, so validators can be shared and extended independently.
+func (v *baseValidator[T, Super]) with(r Rule, check func(T) error) Super {
+	v.mustNotBeSealed(r.Name)
+
+	return v.derive(append(slices.Clip(v.rules), rule[T]{Rule: r, check: check}))
+}
+
+// withSpan is like with for rules that check nothing but that the length of
+// values, as measured by length, is within [min, max].
+func (v *baseValidator[T, Super]) withSpan(r Rule, length func(T) int, min, max int, check func(T) error) Super {
+	v.mustNotBeSealed(r.Name)
+
+	return v.derive(append(slices.Clip(v.rules), rule[T]{Rule: r, check: check, span: &span[T]{length, min, max}}))
+}
+
+// withIn is like with for rules that validate nested values.
+func (v *baseValidator[T, Super]) withIn(r Rule, check func(*Evaluation, T) error) Super {
+	v.mustNotBeSealed(r.Name)
+
+	return v.derive(append(slices.Clip(v.rules), rule[T]{Rule: r, checkIn: check}))
+}
+
+func (v *baseValidator[T, Super]) derive(rules []rule[T]) Super {
+	return v.deriveWith(func(next *baseValidator[T, Super]) {
+		next.rules = rules
+	})
+}
+
+// deriveWith returns a new validator with the rules and settings of v,
+// changed by modify.
+func (v *baseValidator[T, Super]) deriveWith(modify func(*baseValidator[T, Super])) Super {
+	next := &baseValidator[T, Super]{
+		rules:     v.rules,
+		clone:     v.clone,
+		sensitive: v.sensitive,
+		maxErrors: v.maxErrors,
+	}
+	modify(next)
+	next.compiled = fuse(next.rules)
+	next.super = v.clone(next)
+	next.inspectNested()
+
+	return next.super
+}
+
+// check adds an unnamed check of nested values to v in place. It is meant
+// for constructors, before v is handed out.
+func (v *baseValidator[T, Super]) check(check func(*Evaluation, T) error) {
+	v.rules = append(v.rules, rule[T]{checkIn: check})
+	v.compiled = fuse(v.rules)
+	v.inspectNested()
+}
+
+// tracksPaths reports whether validators nested in v need to know the path
+// to the values they validate, as ShadowValidator does to report
+// disagreements, so that v must be run with an evaluation that tracks it.
+func (v *baseValidator[T, Super]) tracksPaths() bool {
+	return v.tracked
+}
+
+// inspectNested records whether validators nested in v, such as element
+// validators and validators passed to rules, track paths or are sensitive.
+func (v *baseValidator[T, Super]) inspectNested() {
+	var nested []any
+	for _, r := range v.rules {
+		nested = append(nested, r.Params...)
+	}
+
+	if p, ok := any(v.super).(interface{ nested() []AnyValidator }); ok {
+		for _, n := range p.nested() {
+			nested = append(nested, n)
+		}
+	}
+
+	v.tracked = slices.ContainsFunc(nested, tracksPaths)
+	v.sensitiveNested = slices.ContainsFunc(nested, showsSensitive)
+}
+
+func tracksPaths(v any) bool {
+	t, ok := v.(interface{ tracksPaths() bool })
+	return ok && t.tracksPaths()
 }
@@ -0,0 +1,291 @@
+package validators_test
+
+import (
+	"errors"
+	"slices"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/bitcrshr/valid/validators"
+)
+
+type benchUser struct {
+	Name  string
+	Email string
+	Age   int
+	Score float64
+}
+
+var benchValue = benchUser{Name: "Ada", Email: "ada@example.com", Age: 36, Score: 9.5}
+
+func benchName() validators.StringValidator[string] {
+	return validators.NewStringValidator[string]().MinLen(2).MaxLen(64)
+}
+
+func benchEmail() validators.StringValidator[string] {
+	return validators.NewStringValidator[string]().Contains("@").MaxLen(254)
+}
+
+func benchAge() validators.NumberValidator[int] {
+	return validators.NewNumberValidator[int]().GTE(0).LTE(150)
+}
+
+func benchScore() validators.NumberValidator[float64] {
+	return validators.NewNumberValidator[float64]().GTE(0).LTE(10)
+}
+
+func benchShape() validators.StructShape {
+	return validators.StructShape{
+		"Name":  benchName(),
+		"Email": benchEmail(),
+		"Age":   benchAge(),
+		"Score": benchScore(),
+	}
+}
+
+// generatedUser validates benchUser like code generated for it would, by
+// reading each field directly instead of with reflection. It serves as a
+// baseline for passing values, so violations are not given paths.
+type generatedUser struct {
+	name  validators.StringValidator[string]
+	email validators.StringValidator[string]
+	age   validators.NumberValidator[int]
+	score validators.NumberValidator[float64]
+}
+
+func (g generatedUser) Validate(u benchUser) error {
+	var errs validators.Errors
+	if err := g.name.Validate(u.Name); err != nil {
+		errs = append(errs, validators.AsErrors(err)...)
+	}
+
+	if err := g.email.Validate(u.Email); err != nil {
+		errs = append(errs, validators.AsErrors(err)...)
+	}
+
+	if err := g.age.Validate(u.Age); err != nil {
+		errs = append(errs, validators.AsErrors(err)...)
+	}
+
+	if err := g.score.Validate(u.Score); err != nil {
+		errs = append(errs, validators.AsErrors(err)...)
+	}
+
+	if len(errs) == 0 {
+		return nil
+	}
+
+	return errs
+}
+
+func BenchmarkString(b *testing.B) {
+	v := benchName()
+
+	b.ReportAllocs()
+	for b.Loop() {
+		if err := v.Validate(benchValue.Name); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkNumber(b *testing.B) {
+	v := benchAge()
+
+	b.ReportAllocs()
+	for b.Loop() {
+		if err := v.Validate(benchValue.Age); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkStruct(b *testing.B) {
+	b.Run("reflection", func(b *testing.B) {
+		// Fields of interface types are looked up on every validation.
+		v := validators.MustStructValidator[any](benchShape())
+
+		b.ReportAllocs()
+		for b.Loop() {
+			if err := v.Validate(benchValue); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+
+	b.Run("cached-reflection", func(b *testing.B) {
+		v := validators.MustStructValidator[benchUser](benchShape())
+
+		b.ReportAllocs()
+		for b.Loop() {
+			if err := v.Validate(benchValue); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+
+	b.Run("generated", func(b *testing.B) {
+		v := generatedUser{name: benchName(), email: benchEmail(), age: benchAge(), score: benchScore()}
+
+		b.ReportAllocs()
+		for b.Loop() {
+			if err := v.Validate(benchValue); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+}
+
+func BenchmarkStringIn(b *testing.B) {
+	haystack := make([]string, 1000)
+	for i := range haystack {
+		haystack[i] = "value-" + strconv.Itoa(i)
+	}
+
+	errNotIn := errors.New("not in haystack")
+	needle := haystack[len(haystack)-1]
+
+	b.Run("set", func(b *testing.B) {
+		v := validators.NewStringValidator[string]().In(haystack...)
+
+		b.ReportAllocs()
+		for b.Loop() {
+			if err := v.Validate(needle); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+
+	b.Run("linear", func(b *testing.B) {
+		v := validators.NewStringValidator[string]().Satisfies(func(s string) error {
+			if !slices.Contains(haystack, s) {
+				return errNotIn
+			}
+
+			return nil
+		})
+
+		b.ReportAllocs()
+		for b.Loop() {
+			if err := v.Validate(needle); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+}
+
+func BenchmarkStringLen(b *testing.B) {
+	errLen := errors.New("bad length")
+
+	b.Run("fused", func(b *testing.B) {
+		v := validators.NewStringValidator[string]().NotEmpty().MinLen(2).MaxLen(64).Len(3)
+
+		b.ReportAllocs()
+		for b.Loop() {
+			if err := v.Validate(benchValue.Name); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+
+	b.Run("separate", func(b *testing.B) {
+		v := validators.NewStringValidator[string]().NotEmpty()
+		for _, ok := range []func(n int) bool{
+			func(n int) bool { return n >= 2 },
+			func(n int) bool { return n <= 64 },
+			func(n int) bool { return n == 3 },
+		} {
+			v = v.Satisfies(func(s string) error {
+				if !ok(len(s)) {
+					return errLen
+				}
+
+				return nil
+			})
+		}
+
+		b.ReportAllocs()
+		for b.Loop() {
+			if err := v.Validate(benchValue.Name); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+}
+
+func BenchmarkStringContainsAtLeast(b *testing.B) {
+	value := strings.Repeat("a,", 2) + strings.Repeat("b", 4096)
+	errCount := errors.New("too few")
+
+	b.Run("early-exit", func(b *testing.B) {
+		v := validators.NewStringValidator[string]().ContainsAtLeast(",", 2)
+
+		b.ReportAllocs()
+		for b.Loop() {
+			if err := v.Validate(value); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+
+	b.Run("count", func(b *testing.B) {
+		v := validators.NewStringValidator[string]().Satisfies(func(s string) error {
+			if strings.Count(s, ",") < 2 {
+				return errCount
+			}
+
+			return nil
+		})
+
+		b.ReportAllocs()
+		for b.Loop() {
+			if err := v.Validate(value); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+}
+
+func TestValidateDoesNotAllocate(t *testing.T) {
+	type Level uint8
+
+	type Flat struct {
+		Name  string
+		Count uint
+		Level Level
+		Ratio float32
+	}
+
+	name, age := benchName(), benchAge()
+	flat := validators.MustStructValidator[Flat](validators.StructShape{
+		"Name":  benchName(),
+		"Count": validators.NewNumberValidator[uint]().Positive(),
+		"Level": validators.NewNumberValidator[Level]().LTE(10),
+		"Ratio": validators.NewNumberValidator[float32]().GT(0),
+	}).NotZero()
+	value := Flat{Name: "Ada", Count: 1, Level: 3, Ratio: 0.5}
+
+	for _, tc := range []struct {
+		name     string
+		validate func() error
+	}{
+		{"string", func() error { return name.Validate(benchValue.Name) }},
+		{"number", func() error { return age.Validate(benchValue.Age) }},
+		{"flat struct", func() error { return flat.Validate(value) }},
+	} {
+		if err := tc.validate(); err != nil {
+			t.Fatalf("%s: expected to pass, got %v", tc.name, err)
+		}
+
+		if allocs := testing.AllocsPerRun(100, func() { _ = tc.validate() }); allocs != 0 {
+			t.Errorf("%s: expected no allocations, got %v", tc.name, allocs)
+		}
+	}
+
+	invalid := Flat{Name: "Ada", Count: 1, Level: 11, Ratio: 0.5}
+	errs := validators.AsErrors(flat.Validate(invalid))
+	if len(errs) != 1 || errs[0].Path != "Level" || errs[0].Code != "lte" || errs[0].Value != Level(11) {
+		t.Errorf("expected Level to fail lte with its value, got %v", errs)
+	}
+}
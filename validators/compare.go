@@ -0,0 +1,601 @@
+package validators
+
+import (
+	"encoding/json"
+	"fmt"
+	"maps"
+	"math"
+	"slices"
+	"strings"
+)
+
+// Effect classifies a change to a validator by how it affects the values the
+// validator accepts, see Compare.
+type Effect int
+
+const (
+	// Neutral changes do not change which values are valid, such as added
+	// warnings.
+	Neutral Effect = iota
+	// Loosening changes make invalid values valid and keep every valid value
+	// valid, such as a higher MaxLen.
+	Loosening
+	// Tightening changes make valid values invalid, such as a lower MaxLen.
+	// They may also make invalid values valid, as a different Len does.
+	Tightening
+	// Unknown changes cannot be classified from the descriptions, such as
+	// added or removed Satisfies checks.
+	Unknown
+)
+
+func (e Effect) String() string {
+	switch e {
+	case Neutral:
+		return "neutral"
+	case Loosening:
+		return "loosening"
+	case Tightening:
+		return "tightening"
+	case Unknown:
+		return "unknown"
+	}
+
+	return fmt.Sprintf("Effect(%d)", int(e))
+}
+
+// ChangeKind tells whether a rule or validator was added, removed or changed.
+type ChangeKind int
+
+const (
+	Added ChangeKind = iota
+	Removed
+	Changed
+)
+
+func (k ChangeKind) String() string {
+	switch k {
+	case Added:
+		return "added"
+	case Removed:
+		return "removed"
+	case Changed:
+		return "changed"
+	}
+
+	return fmt.Sprintf("ChangeKind(%d)", int(k))
+}
+
+// Change is a difference between two versions of a validator, see Compare.
+type Change struct {
+	// Path is the path to the value whose validator changed, like the paths
+	// of Error, with "[]" for the elements of slices.
+	Path string
+	Kind ChangeKind
+	// Rule is the name of the rule that was added, removed or changed, or ""
+	// if the validator at Path was added, removed or replaced as a whole, or
+	// if its bounds changed from those of one rule to those of another.
+	Rule string
+	// Old and New render the rule or validator before and after the change,
+	// as Description.String does, with the types of validators whose type
+	// changed. Old is empty for additions, and New for removals.
+	Old, New string
+	Effect   Effect
+}
+
+// String renders c as text, e.g. Name: changed maxLen=20 to maxLen=10
+// (tightening).
+func (c Change) String() string {
+	var s string
+	switch c.Kind {
+	case Added:
+		s = "added " + c.New
+	case Removed:
+		s = "removed " + c.Old
+	default:
+		s = "changed " + c.Old + " to " + c.New
+	}
+
+	if c.Path != "" {
+		s = c.Path + ": " + s
+	}
+
+	return s + " (" + c.Effect.String() + ")"
+}
+
+// Changes are the differences between two versions of a validator.
+type Changes []Change
+
+// Breaking reports whether any of cs may make valid values invalid, that is
+// whether it is Tightening or Unknown.
+func (cs Changes) Breaking() bool {
+	return slices.ContainsFunc(cs, func(c Change) bool { return c.Effect >= Tightening })
+}
+
+// String renders cs as text, a change per line.
+func (cs Changes) String() string {
+	lines := make([]string, len(cs))
+	for i, c := range cs {
+		lines[i] = c.String()
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+// Compare returns the differences between old and new, two versions of the
+// description of a validator, such as a snapshot decoded from JSON and the
+// description of the current validator, in the order of Description.String.
+//
+// Bounds of the length or value, such as MinLen and GT, are compared by the
+// range of values they allow together, so that replacing GT(1) with GTE(1)
+// is loosening and dropping a MinLen(3) next to a MinLen(5) changes nothing.
+// Other rules are matched by name, and rules of the same name by their
+// order. Added rules and fields with rules are tightening, removed ones
+// loosening, and changed params are classified by the rule, e.g. an In with
+// fewer values is tightening. Satisfies checks, custom validators and params
+// of rules whose effect cannot be told, such as Matches, are Unknown.
+// Validators whose kind or type changed are reported as replaced, with an
+// Unknown effect, without comparing their rules. Changes to Sensitive and
+// MaxErrors do not affect which values are valid and are not reported.
+func Compare(old, new Description) Changes {
+	var cs Changes
+	compareDescriptions(&cs, old, new, "")
+
+	return cs
+}
+
+func compareDescriptions(cs *Changes, old, new Description, path string) {
+	if old.Kind != new.Kind || old.Type != new.Type {
+		o, n := old.head(), new.head()
+		if old.Type != new.Type {
+			o += " of " + old.Type
+			n += " of " + new.Type
+		}
+
+		*cs = append(*cs, Change{Path: path, Kind: Changed, Old: o, New: n, Effect: Unknown})
+		return
+	}
+
+	compareRules(cs, old.Rules, new.Rules, path, old.Integer || new.Integer)
+
+	elemPath := path
+	if new.Kind == "slice" {
+		elemPath += "[]"
+	}
+
+	compareNested(cs, old.Elem, new.Elem, elemPath)
+
+	names := slices.Collect(maps.Keys(old.Fields))
+	for name := range new.Fields {
+		if _, ok := old.Fields[name]; !ok {
+			names = append(names, name)
+		}
+	}
+
+	slices.Sort(names)
+	for _, name := range names {
+		var o, n *Description
+		if d, ok := old.Fields[name]; ok {
+			o = &d
+		}
+
+		if d, ok := new.Fields[name]; ok {
+			n = &d
+		}
+
+		compareNested(cs, o, n, joinPath(path, name))
+	}
+}
+
+// compareNested compares the validators of an element or field, either of
+// which may be missing.
+func compareNested(cs *Changes, old, new *Description, path string) {
+	switch {
+	case old == nil && new == nil:
+	case old == nil:
+		*cs = append(*cs, Change{Path: path, Kind: Added, New: new.head(), Effect: addedEffect(*new)})
+	case new == nil:
+		*cs = append(*cs, Change{Path: path, Kind: Removed, Old: old.head(), Effect: removedEffect(addedEffect(*old))})
+	default:
+		compareDescriptions(cs, *old, *new, path)
+	}
+}
+
+// addedEffect returns the effect of adding the validator d where there was
+// none.
+func addedEffect(d Description) Effect {
+	if d.Kind == "custom" {
+		return Unknown
+	}
+
+	e := Neutral
+	for _, r := range d.Rules {
+		e = max(e, addedRuleEffect(r))
+	}
+
+	if d.Elem != nil {
+		e = max(e, addedEffect(*d.Elem))
+	}
+
+	for _, f := range d.Fields {
+		e = max(e, addedEffect(f))
+	}
+
+	return e
+}
+
+func addedRuleEffect(r Rule) Effect {
+	switch {
+	case r.Warning:
+		return Neutral
+	case r.Name == "satisfies":
+		return Unknown
+	}
+
+	return Tightening
+}
+
+// removedEffect returns the effect of removing what adding has effect e.
+func removedEffect(e Effect) Effect {
+	if e == Tightening {
+		return Loosening
+	}
+
+	return e
+}
+
+// compareRules compares the rules of two validators, whose bounds are on
+// integers if integer is true.
+func compareRules(cs *Changes, old, new []Rule, path string, integer bool) {
+	match := matchRules(old, new)
+
+	// Bounds are compared together, by the range of values they allow.
+	var oldBounds, newBounds []Rule
+	for i, o := range old {
+		j := match[i]
+		switch {
+		case isBound(o) && (j < 0 || isBound(new[j])):
+			oldBounds = append(oldBounds, o)
+		case j < 0:
+			*cs = append(*cs, Change{Path: path, Kind: Removed, Rule: o.Name, Old: ruleString(o), Effect: removedEffect(addedRuleEffect(o))})
+		default:
+			if e, changed := compareRule(o, new[j]); changed {
+				*cs = append(*cs, Change{Path: path, Kind: Changed, Rule: o.Name, Old: ruleString(o), New: ruleString(new[j]), Effect: e})
+			}
+		}
+	}
+
+	// Rules of new matched to ones of old that are not both bounds were
+	// compared above.
+	compared := make([]bool, len(new))
+	for i, j := range match {
+		if j >= 0 {
+			compared[j] = !isBound(old[i]) || !isBound(new[j])
+		}
+	}
+
+	var added Changes
+	for j, n := range new {
+		switch {
+		case compared[j]:
+		case isBound(n):
+			newBounds = append(newBounds, n)
+		default:
+			added = append(added, Change{Path: path, Kind: Added, Rule: n.Name, New: ruleString(n), Effect: addedRuleEffect(n)})
+		}
+	}
+
+	compareBounds(cs, oldBounds, newBounds, path, integer)
+	*cs = append(*cs, added...)
+}
+
+// matchRules matches the rules old to new ones of the same name, in their
+// order, preferring rules that are both bounds or both not. It returns the
+// index of the new rule matched to each old one, or -1 if there is none.
+func matchRules(old, new []Rule) []int {
+	match := make([]int, len(old))
+	for i := range match {
+		match[i] = -1
+	}
+
+	matched := make([]bool, len(new))
+	for _, sameBound := range []bool{true, false} {
+		for i, o := range old {
+			if match[i] >= 0 {
+				continue
+			}
+
+			for j, n := range new {
+				if !matched[j] && n.Name == o.Name && (!sameBound || isBound(n) == isBound(o)) {
+					match[i], matched[j] = j, true
+					break
+				}
+			}
+		}
+	}
+
+	return match
+}
+
+// isBound reports whether r bounds the length or value of every value
+// validated, unlike warnings and rules checked in groups only.
+func isBound(r Rule) bool {
+	switch r.Name {
+	case "len", "minLen", "maxLen", "gt", "gte", "lt", "lte":
+		return !r.Warning && len(r.Groups) == 0
+	}
+
+	return false
+}
+
+// compareBounds reports a change if the bounds old and new, rules for which
+// isBound is true, allow different ranges of values, whatever the rules that
+// set them, e.g. Len(3) and MinLen(3).MaxLen(3) are the same, and so are
+// GT(5) and GTE(6) on integers.
+func compareBounds(cs *Changes, old, new []Rule, path string, integer bool) {
+	rules := slices.Concat(old, new)
+	length := slices.ContainsFunc(rules, func(r Rule) bool {
+		return r.Name == "len" || r.Name == "minLen" || r.Name == "maxLen"
+	})
+
+	o, oOK := boundsOf(old, length, integer)
+	n, nOK := boundsOf(new, length, integer)
+
+	e := Unknown
+	switch {
+	case !oOK || !nOK:
+		if setEffect(ruleStrings(old), ruleStrings(new)) == Neutral {
+			return
+		}
+	case o == n:
+		return
+	case n.contains(o):
+		e = Loosening
+	default:
+		e = Tightening
+	}
+
+	c := Change{Path: path, Kind: Changed, Old: strings.Join(ruleStrings(old), ", "), New: strings.Join(ruleStrings(new), ", "), Effect: e}
+	switch {
+	case len(old) == 0:
+		c.Kind = Added
+	case len(new) == 0:
+		c.Kind = Removed
+	}
+
+	if !slices.ContainsFunc(rules, func(r Rule) bool { return r.Name != rules[0].Name }) {
+		// Bounds of different rules changed together are not named.
+		c.Rule = rules[0].Name
+	}
+
+	*cs = append(*cs, c)
+}
+
+// bounds is a range of lengths or numbers.
+type bounds struct {
+	lo, hi         float64
+	loOpen, hiOpen bool
+}
+
+// boundsOf returns the range of values the bounds rs allow, lengths from 0
+// if length is true, with inclusive ends if the values are integers or
+// lengths. It reports false if a param is not a number.
+func boundsOf(rs []Rule, length, integer bool) (bounds, bool) {
+	b := bounds{lo: math.Inf(-1), hi: math.Inf(1)}
+	if length {
+		b.lo = 0
+	}
+
+	for _, r := range rs {
+		var x float64
+		if len(r.Params) != 1 || json.Unmarshal([]byte(canonical(r.Params[0])), &x) != nil {
+			return b, false
+		}
+
+		switch r.Name {
+		case "gt":
+			if x >= b.lo {
+				b.lo, b.loOpen = x, true
+			}
+		case "lt":
+			if x <= b.hi {
+				b.hi, b.hiOpen = x, true
+			}
+		}
+
+		if r.Name == "gte" || r.Name == "minLen" || r.Name == "len" {
+			if x > b.lo {
+				b.lo, b.loOpen = x, false
+			}
+		}
+
+		if r.Name == "lte" || r.Name == "maxLen" || r.Name == "len" {
+			if x < b.hi {
+				b.hi, b.hiOpen = x, false
+			}
+		}
+	}
+
+	if length || integer {
+		b = b.inclusive()
+	}
+
+	return b, true
+}
+
+// inclusive returns b as a range of integers with inclusive ends, e.g.
+// [6, 9] for (5, 10).
+func (b bounds) inclusive() bounds {
+	if b.loOpen {
+		b.lo = math.Floor(b.lo) + 1
+	} else {
+		b.lo = math.Ceil(b.lo)
+	}
+
+	if b.hiOpen {
+		b.hi = math.Ceil(b.hi) - 1
+	} else {
+		b.hi = math.Floor(b.hi)
+	}
+
+	b.loOpen, b.hiOpen = false, false
+
+	return b
+}
+
+func (b bounds) empty() bool {
+	return b.lo > b.hi || (b.lo == b.hi && (b.loOpen || b.hiOpen))
+}
+
+// contains reports whether b allows every value that c allows.
+func (b bounds) contains(c bounds) bool {
+	if c.empty() {
+		return true
+	}
+
+	lower := b.lo < c.lo || (b.lo == c.lo && (!b.loOpen || c.loOpen))
+	upper := b.hi > c.hi || (b.hi == c.hi && (!b.hiOpen || c.hiOpen))
+
+	return lower && upper
+}
+
+func ruleStrings(rs []Rule) []string {
+	ss := make([]string, len(rs))
+	for i, r := range rs {
+		ss[i] = ruleString(r)
+	}
+
+	return ss
+}
+
+// compareRule returns the effect of changing old to new, two rules of the
+// same name, and whether they differ at all.
+func compareRule(old, new Rule) (Effect, bool) {
+	params := paramsEffect(old.Name, old.Params, new.Params)
+	groups := setEffect(old.Groups, new.Groups)
+	if len(old.Groups) == 0 && len(new.Groups) > 0 {
+		// Rules in no group are checked in every group.
+		groups = Loosening
+	} else if len(new.Groups) == 0 && len(old.Groups) > 0 {
+		groups = Tightening
+	}
+
+	changed := params != Neutral || groups != Neutral || old.Warning != new.Warning
+	switch {
+	case old.Warning && new.Warning:
+		return Neutral, changed
+	case old.Warning:
+		return max(Tightening, addedRuleEffect(new)), changed
+	case new.Warning:
+		return removedEffect(addedRuleEffect(old)), changed
+	}
+
+	return max(params, groups), changed
+}
+
+// paramsEffect returns the effect of changing the params of the rule name
+// from old to new.
+func paramsEffect(name string, old, new []any) Effect {
+	o, n := canonicals(old), canonicals(new)
+	if slices.Equal(o, n) {
+		return Neutral
+	}
+
+	switch name {
+	case "minLen", "gt", "gte":
+		return boundEffect(o, n, 0, 1)
+	case "maxLen", "lt", "lte":
+		return boundEffect(o, n, 0, -1)
+	case "containsAtLeast", "containsAtMost":
+		if len(o) != 2 || len(n) != 2 || o[0] != n[0] {
+			return Unknown
+		}
+
+		if name == "containsAtLeast" {
+			return boundEffect(o, n, 1, 1)
+		}
+
+		return boundEffect(o, n, 1, -1)
+	case "len", "equalTo", "notEqualTo", "containsExact", "hasKey", "notHasKey":
+		// Values that passed the old params fail the new ones.
+		return Tightening
+	case "in", "hasKeyIn":
+		return setEffect(n, o)
+	case "notIn", "notHasKeyIn":
+		return setEffect(o, n)
+	}
+
+	return Unknown
+}
+
+// boundEffect returns the effect of changing the bound at i of the params
+// old to that of new. The bound is a lower one if sign is 1 and an upper one
+// if it is -1.
+func boundEffect(old, new []string, i int, sign float64) Effect {
+	if i >= len(old) || i >= len(new) {
+		return Unknown
+	}
+
+	var o, n float64
+	if json.Unmarshal([]byte(old[i]), &o) != nil || json.Unmarshal([]byte(new[i]), &n) != nil {
+		return Unknown
+	}
+
+	if (n-o)*sign > 0 {
+		return Tightening
+	}
+
+	return Loosening
+}
+
+// setEffect returns the effect of changing a set from old to new, where a
+// larger set rejects more values, as the values of NotIn or the groups of a
+// rule do: it is Loosening if new is a subset of old, and Tightening unless
+// they are equal otherwise.
+func setEffect(old, new []string) Effect {
+	switch {
+	case subset(old, new) && subset(new, old):
+		return Neutral
+	case subset(new, old):
+		return Loosening
+	}
+
+	return Tightening
+}
+
+func subset(s, of []string) bool {
+	for _, x := range s {
+		if !slices.Contains(of, x) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// canonicals returns params in a form that compares equal for equal values,
+// whether they are Go values or were decoded from JSON: their JSON encodings,
+// normalized by decoding and encoding them again.
+func canonicals(params []any) []string {
+	cs := make([]string, len(params))
+	for i, p := range params {
+		cs[i] = canonical(p)
+	}
+
+	return cs
+}
+
+func canonical(p any) string {
+	b, err := json.Marshal(p)
+	if err != nil {
+		return fmt.Sprintf("%T", p)
+	}
+
+	var v any
+	if json.Unmarshal(b, &v) == nil {
+		if nb, err := json.Marshal(v); err == nil {
+			return string(nb)
+		}
+	}
+
+	return string(b)
+}
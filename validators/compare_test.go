@@ -0,0 +1,233 @@
+package validators_test
+
+import (
+	"encoding/json"
+	"regexp"
+	"testing"
+
+	"github.com/bitcrshr/valid/validators"
+)
+
+func TestCompare(t *testing.T) {
+	str := validators.NewStringValidator[string]
+	num := validators.NewNumberValidator[int]
+
+	tests := []struct {
+		name     string
+		old, new validators.AnyValidator
+		want     string
+		breaking bool
+	}{
+		{
+			name: "unchanged",
+			old:  str().NotEmpty().MaxLen(20).In("a", "b"),
+			new:  str().NotEmpty().MaxLen(20).In("a", "b"),
+		},
+		{
+			name:     "added rule",
+			old:      str().NotEmpty(),
+			new:      str().NotEmpty().ValidUUID(),
+			want:     "added uuid (tightening)",
+			breaking: true,
+		},
+		{
+			name: "removed rule",
+			old:  str().NotEmpty().ValidUUID(),
+			new:  str().NotEmpty(),
+			want: "removed uuid (loosening)",
+		},
+		{
+			name:     "lower max len",
+			old:      str().MaxLen(20),
+			new:      str().MaxLen(10),
+			want:     "changed maxLen=20 to maxLen=10 (tightening)",
+			breaking: true,
+		},
+		{
+			name: "lower min",
+			old:  num().GTE(18),
+			new:  num().GTE(16),
+			want: "changed gte=18 to gte=16 (loosening)",
+		},
+		{
+			name: "gt to gte",
+			old:  num().GT(1),
+			new:  num().GTE(1),
+			want: "changed gt=1 to gte=1 (loosening)",
+		},
+		{
+			name: "gt to gte on integers",
+			old:  num().GT(5).LT(10),
+			new:  num().GTE(6).LTE(9),
+		},
+		{
+			name:     "gt to gte on floats",
+			old:      validators.NewNumberValidator[float64]().GT(5),
+			new:      validators.NewNumberValidator[float64]().GTE(6),
+			want:     "changed gt=5 to gte=6 (tightening)",
+			breaking: true,
+		},
+		{
+			name: "redundant min len dropped",
+			old:  str().MinLen(3).MinLen(5),
+			new:  str().MinLen(5),
+		},
+		{
+			name: "len to min len",
+			old:  str().Len(3),
+			new:  str().MinLen(3),
+			want: "changed len=3 to minLen=3 (loosening)",
+		},
+		{
+			name: "len to min and max len",
+			old:  str().Len(3),
+			new:  str().MinLen(3).MaxLen(3),
+		},
+		{
+			name:     "narrower range",
+			old:      num().GT(0).LT(10),
+			new:      num().GTE(1).LTE(9).LT(8),
+			want:     "changed gt=0, lt=10 to gte=1, lte=9, lt=8 (tightening)",
+			breaking: true,
+		},
+		{
+			name:     "different len",
+			old:      str().Len(5),
+			new:      str().Len(6),
+			want:     "changed len=5 to len=6 (tightening)",
+			breaking: true,
+		},
+		{
+			name: "more values in",
+			old:  str().In("a", "b"),
+			new:  str().In("b", "a", "c"),
+			want: `changed in=("a", "b") to in=("b", "a", "c") (loosening)`,
+		},
+		{
+			name: "reordered values in",
+			old:  num().In(1, 2),
+			new:  num().In(2, 1),
+		},
+		{
+			name:     "more values not in",
+			old:      num().NotIn(1),
+			new:      num().NotIn(1, 2),
+			want:     "changed notIn=1 to notIn=(1, 2) (tightening)",
+			breaking: true,
+		},
+		{
+			name:     "other pattern",
+			old:      str().Matches(regexp.MustCompile(`^a`)),
+			new:      str().Matches(regexp.MustCompile(`^b`)),
+			want:     "changed matches=^a to matches=^b (unknown)",
+			breaking: true,
+		},
+		{
+			name:     "added satisfies",
+			old:      num(),
+			new:      num().Satisfies(func(int) error { return nil }),
+			want:     "added satisfies (unknown)",
+			breaking: true,
+		},
+		{
+			name: "added warning",
+			old:  str(),
+			new:  str().MaxLen(5).AsWarning(),
+			want: "added maxLen=5 [warning] (neutral)",
+		},
+		{
+			name:     "warning to error",
+			old:      str().MaxLen(5).AsWarning(),
+			new:      str().MaxLen(5),
+			want:     "changed maxLen=5 [warning] to maxLen=5 (tightening)",
+			breaking: true,
+		},
+		{
+			name: "moved to group",
+			old:  str().ValidUUID(),
+			new:  str().ValidUUID().Groups("create"),
+			want: "changed uuid to uuid [create] (loosening)",
+		},
+		{
+			name:     "other type",
+			old:      num().GT(0),
+			new:      validators.NewNumberValidator[int64]().GT(0),
+			want:     "changed number(gt=0) of int to number(gt=0) of int64 (unknown)",
+			breaking: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			changes := validators.Compare(validators.Describe(tt.old), validators.Describe(tt.new))
+			if got := changes.String(); got != tt.want {
+				t.Errorf("expected changes %q, got %q", tt.want, got)
+			}
+
+			if changes.Breaking() != tt.breaking {
+				t.Errorf("expected breaking to be %v for %v", tt.breaking, changes)
+			}
+		})
+	}
+}
+
+func TestCompareFields(t *testing.T) {
+	type Address struct {
+		City string
+		Zip  string
+	}
+
+	type User struct {
+		Name    string
+		Tags    []string
+		Address *Address
+	}
+
+	old := validators.MustStructValidator[User](validators.StructShape{
+		"Name": validators.NewStringValidator[string]().MinLen(2),
+		"Tags": validators.NewSliceValidator[[]string](validators.NewStringValidator[string]().NotEmpty()),
+		"Address": validators.NewPointerValidator(validators.MustStructValidator[Address](validators.StructShape{
+			"City": validators.NewStringValidator[string]().NotEmpty(),
+		})),
+	})
+
+	new := validators.MustStructValidator[User](validators.StructShape{
+		"Name": validators.NewStringValidator[string]().MinLen(2),
+		"Tags": validators.NewSliceValidator[[]string](validators.NewStringValidator[string]().NotEmpty().MaxLen(10)),
+		"Address": validators.NewPointerValidator(validators.MustStructValidator[Address](validators.StructShape{
+			"Zip": validators.NewStringValidator[string]().Len(5),
+		})),
+	})
+
+	want := "Address.City: removed string(notEmpty) (loosening)\n" +
+		"Address.Zip: added string(len=5) (tightening)\n" +
+		"Tags[]: added maxLen=10 (tightening)"
+	if got := validators.Compare(validators.Describe(old), validators.Describe(new)).String(); got != want {
+		t.Errorf("expected changes\n%s\ngot\n%s", want, got)
+	}
+}
+
+func TestCompareSnapshot(t *testing.T) {
+	v := validators.NewSliceValidator[[]string](
+		validators.NewStringValidator[string]().Matches(regexp.MustCompile(`^[a-z]+$`)).In("ab", "cd"),
+	).MinLen(1).AllSatisfy(validators.NewStringValidator[string]().MaxLen(2))
+
+	data, err := json.Marshal(validators.Describe(v))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var snapshot validators.Description
+	if err := json.Unmarshal(data, &snapshot); err != nil {
+		t.Fatal(err)
+	}
+
+	if changes := validators.Compare(snapshot, validators.Describe(v)); len(changes) > 0 {
+		t.Errorf("expected no changes since the snapshot, got:\n%s", changes)
+	}
+
+	stricter := v.MinLen(2)
+	if changes := validators.Compare(snapshot, validators.Describe(stricter)); !changes.Breaking() {
+		t.Errorf("expected a higher min len to be breaking, got:\n%s", changes)
+	}
+}
@@ -0,0 +1,120 @@
+package validators
+
+import (
+	"errors"
+	"math"
+	"slices"
+	"strings"
+)
+
+// setThreshold is the number of values from which In and NotIn look values
+// up in a hash set rather than comparing them one by one.
+const setThreshold = 8
+
+// contains returns a function that reports whether haystack contains a
+// value. Haystacks of setThreshold or more values are compiled into a set.
+func contains[T comparable](haystack []T) func(T) bool {
+	if len(haystack) < setThreshold {
+		return func(t T) bool {
+			return slices.Contains(haystack, t)
+		}
+	}
+
+	set := make(map[T]struct{}, len(haystack))
+	for _, h := range haystack {
+		set[h] = struct{}{}
+	}
+
+	return func(t T) bool {
+		_, ok := set[t]
+		return ok
+	}
+}
+
+// countUpTo returns the number of non-overlapping instances of needle in s
+// like strings.Count, but stops searching once it has found limit of them.
+func countUpTo(s, needle string, limit int) int {
+	if needle == "" {
+		return min(strings.Count(s, needle), limit)
+	}
+
+	n := 0
+	for n < limit {
+		i := strings.Index(s, needle)
+		if i < 0 {
+			break
+		}
+
+		n++
+		s = s[i+len(needle):]
+	}
+
+	return n
+}
+
+// span bounds the length of values, as measured by length, to [min, max],
+// for rules that check nothing else, such as MinLen.
+type span[T any] struct {
+	length   func(T) int
+	min, max int
+}
+
+func stringLen[T ~string](t T) int {
+	return len(t)
+}
+
+func sliceLen[S ~[]E, E any](s S) int {
+	return len(s)
+}
+
+// errOutOfSpan is returned by the checks of fused rules, see fuse.
+var errOutOfSpan = errors.New("validators: length out of span")
+
+// fuse returns rules with every run of adjacent rules that bound the length of
+// values, and are in the same groups, fused into one rule that measures the
+// length only once. The check of a fused rule passes if and only if those of
+// its parts all do; only if it fails are they checked one by one, to report
+// the violated rule.
+func fuse[T any](rules []rule[T]) []rule[T] {
+	fused := make([]rule[T], 0, len(rules))
+	for i := 0; i < len(rules); {
+		j := i + 1
+		for j < len(rules) && fusable(rules[i], rules[j]) {
+			j++
+		}
+
+		if j-i == 1 {
+			fused = append(fused, rules[i])
+		} else {
+			fused = append(fused, fuseSpans(rules[i:j]))
+		}
+
+		i = j
+	}
+
+	return fused
+}
+
+func fusable[T any](a, b rule[T]) bool {
+	return a.span != nil && b.span != nil && a.Warning == b.Warning && slices.Equal(a.Groups, b.Groups)
+}
+
+func fuseSpans[T any](parts []rule[T]) rule[T] {
+	length := parts[0].span.length
+	lower, upper := 0, math.MaxInt
+	for _, p := range parts {
+		lower, upper = max(lower, p.span.min), min(upper, p.span.max)
+	}
+
+	return rule[T]{
+		Rule: Rule{Groups: parts[0].Groups, Warning: parts[0].Warning},
+		check: func(t T) error {
+			if n := length(t); n < lower || n > upper {
+				return errOutOfSpan
+			}
+
+			return nil
+		},
+		parts: slices.Clip(parts),
+	}
+}
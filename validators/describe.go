@@ -0,0 +1,202 @@
+package validators
+
+import (
+	"maps"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// Description is a validator represented as data: the type of the values it
+// validates, its rules and the descriptions of the validators nested in it.
+// Descriptions encode to JSON, e.g. to keep a snapshot for Compare; decoded
+// params are plain JSON values rather than the original Go values.
+type Description struct {
+	// Kind is one of "string", "number", "slice", "map", "pointer", "struct"
+	// or "custom" for validators that cannot describe themselves.
+	Kind string `json:"kind"`
+	// Type is the Go type of the validated values, e.g. "[]string".
+	Type string `json:"type"`
+	// Integer is set for number validators of integer types.
+	Integer bool   `json:"integer,omitempty"`
+	Rules   []Rule `json:"rules,omitempty"`
+	// Elem describes the element validator of slice and pointer validators.
+	Elem *Description `json:"elem,omitempty"`
+	// Fields describes the shape of struct validators.
+	Fields map[string]Description `json:"fields,omitempty"`
+	// Sensitive is set for validators whose values are redacted in errors,
+	// see Sensitive.
+	Sensitive bool `json:"sensitive,omitempty"`
+	// MaxErrors is the limit set with MaxErrors, or 0.
+	MaxErrors int `json:"maxErrors,omitempty"`
+}
+
+// Describer is implemented by validators that can describe themselves.
+type Describer interface {
+	Describe() Description
+}
+
+// Describe returns the description of v. Validators that do not implement
+// Describer are described as custom validators with no rules.
+func Describe(v AnyValidator) Description {
+	if d, ok := v.(Describer); ok {
+		return d.Describe()
+	}
+
+	d := Description{Kind: "custom"}
+	if t := valueType(v); t != nil {
+		d.Type = t.String()
+	}
+
+	return d
+}
+
+// valueType returns the type of the values v validates, as declared by its
+// Validate method, or nil if v has no such method.
+func valueType(v AnyValidator) reflect.Type {
+	if v == nil {
+		return nil
+	}
+
+	m, ok := reflect.TypeOf(v).MethodByName("Validate")
+	if !ok || m.Type.NumIn() != 2 {
+		return nil
+	}
+
+	return m.Type.In(1)
+}
+
+// String renders v as text, e.g. string(notEmpty, minLen=5, uuid), see
+// Description.String.
+func (v *baseValidator[T, Super]) String() string {
+	return Describe(v.super).String()
+}
+
+func (v *baseValidator[T, Super]) describe(kind string) Description {
+	rules := v.Rules()
+	for i, r := range rules {
+		// Validators passed as params, e.g. to AllSatisfy, are described
+		// rather than exposed.
+		var ps []any
+		for j, p := range r.Params {
+			if pv, ok := p.(AnyValidator); ok {
+				if ps == nil {
+					ps = append([]any(nil), r.Params...)
+				}
+
+				ps[j] = Describe(pv)
+			}
+		}
+
+		if ps != nil {
+			rules[i].Params = ps
+		}
+	}
+
+	return Description{
+		Kind:      kind,
+		Type:      reflect.TypeFor[T]().String(),
+		Rules:     rules,
+		Sensitive: v.sensitive,
+		MaxErrors: v.maxErrors,
+	}
+}
+
+// String renders d as text: its kind and rules on one line, e.g.
+// string(notEmpty, minLen=5, uuid), followed by the descriptions of its
+// element and fields, indented below it.
+func (d Description) String() string {
+	var b strings.Builder
+	d.write(&b, "")
+
+	return strings.TrimSuffix(b.String(), "\n")
+}
+
+func (d Description) write(b *strings.Builder, indent string) {
+	b.WriteString(d.head() + "\n")
+
+	if d.Elem != nil {
+		label := "[]"
+		if d.Kind == "pointer" {
+			label = "*"
+		}
+
+		b.WriteString(indent + "  " + label + ": ")
+		d.Elem.write(b, indent+"  ")
+	}
+
+	for _, name := range slices.Sorted(maps.Keys(d.Fields)) {
+		b.WriteString(indent + "  " + name + ": ")
+		d.Fields[name].write(b, indent+"  ")
+	}
+}
+
+// head renders d without its element and fields.
+func (d Description) head() string {
+	var items []string
+	for _, r := range d.Rules {
+		items = append(items, ruleString(r))
+	}
+
+	if d.Sensitive {
+		items = append(items, "sensitive")
+	}
+
+	if d.MaxErrors > 0 {
+		items = append(items, "maxErrors="+strconv.Itoa(d.MaxErrors))
+	}
+
+	if len(items) == 0 {
+		return d.Kind
+	}
+
+	return d.Kind + "(" + strings.Join(items, ", ") + ")"
+}
+
+// ruleString renders r as its name and params, e.g. minLen=5 or
+// containsAtLeast=("a", 2), followed by its groups and whether it is a
+// warning.
+func ruleString(r Rule) string {
+	s := r.Name
+	switch len(r.Params) {
+	case 0:
+	case 1:
+		s += "=" + formatParam(r.Params[0])
+	default:
+		ps := make([]string, len(r.Params))
+		for i, p := range r.Params {
+			ps[i] = formatParam(p)
+		}
+
+		s += "=(" + strings.Join(ps, ", ") + ")"
+	}
+
+	tags := slices.Clone(r.Groups)
+	if r.Warning {
+		tags = append(tags, "warning")
+	}
+
+	if len(tags) > 0 {
+		s += " [" + strings.Join(tags, ", ") + "]"
+	}
+
+	return s
+}
+
+// formatParam formats p, a param of a rule. Strings are quoted, and nested
+// validators are shown by their kind and rules.
+func formatParam(p any) string {
+	switch p := p.(type) {
+	case Description:
+		return p.head()
+	case AnyValidator:
+		return Describe(p).head()
+	}
+
+	if reflect.ValueOf(p).Kind() == reflect.String {
+		return formatValue(p, "%q", maxLogLen)
+	}
+
+	return formatValue(p, "%v", maxLogLen)
+}
@@ -0,0 +1,139 @@
+package validators_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/bitcrshr/valid/validators"
+)
+
+func TestDescribe(t *testing.T) {
+	type Item struct {
+		SKU string
+	}
+
+	type Order struct {
+		ID    string
+		Items []Item
+	}
+
+	v := validators.NewPointerValidator(
+		validators.MustStructValidator[Order](validators.StructShape{
+			"ID": validators.NewStringValidator[string]().NotEmpty().MaxLen(36),
+			"Items": validators.NewSliceValidator[[]Item](
+				validators.MustStructValidator[Item](validators.StructShape{
+					"SKU": validators.NewStringValidator[string]().In("a", "b"),
+				}),
+			).MinLen(1),
+		}),
+	).NotNil()
+
+	want := validators.Description{
+		Kind:  "pointer",
+		Type:  "*validators_test.Order",
+		Rules: []validators.Rule{{Name: "notNil"}},
+		Elem: &validators.Description{
+			Kind:  "struct",
+			Type:  "validators_test.Order",
+			Rules: []validators.Rule{},
+			Fields: map[string]validators.Description{
+				"ID": {
+					Kind:  "string",
+					Type:  "string",
+					Rules: []validators.Rule{{Name: "notEmpty"}, {Name: "maxLen", Params: []any{36}}},
+				},
+				"Items": {
+					Kind:  "slice",
+					Type:  "[]validators_test.Item",
+					Rules: []validators.Rule{{Name: "minLen", Params: []any{1}}},
+					Elem: &validators.Description{
+						Kind:  "struct",
+						Type:  "validators_test.Item",
+						Rules: []validators.Rule{},
+						Fields: map[string]validators.Description{
+							"SKU": {
+								Kind:  "string",
+								Type:  "string",
+								Rules: []validators.Rule{{Name: "in", Params: []any{"a", "b"}}},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	if got := v.Describe(); !reflect.DeepEqual(got, want) {
+		t.Errorf("expected description\n%#v\ngot\n%#v", want, got)
+	}
+}
+
+type customValidator struct{}
+
+func (customValidator) Validate(int) error          { return nil }
+func (customValidator) ValidateAny(value any) error { return nil }
+
+func TestDescribeCustom(t *testing.T) {
+	got := validators.Describe(customValidator{})
+	want := validators.Description{Kind: "custom", Type: "int"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("expected %#v, got %#v", want, got)
+	}
+
+	elem := validators.NewStringValidator[string]().NotEmpty()
+	slice := validators.NewSliceValidator[[]string](elem).AllSatisfy(elem)
+	if p := slice.Rules()[0].Params[0]; p != elem {
+		t.Errorf("expected Rules to return the AllSatisfy validator, got %#v", p)
+	}
+
+	if p := slice.Describe().Rules[0].Params[0]; !reflect.DeepEqual(p, elem.Describe()) {
+		t.Errorf("expected Describe to describe the AllSatisfy validator, got %#v", p)
+	}
+}
+
+func TestValidatorString(t *testing.T) {
+	type Address struct {
+		City string
+	}
+
+	type User struct {
+		Name    string
+		Tags    []string
+		Address *Address
+	}
+
+	if got, want := validators.NewStringValidator[string]().NotEmpty().MinLen(5).ValidUUID().String(), "string(notEmpty, minLen=5, uuid)"; got != want {
+		t.Errorf("expected %s, got %s", want, got)
+	}
+
+	v := validators.MustStructValidator[User](validators.StructShape{
+		"Name": validators.NewStringValidator[string]().
+			ContainsAtLeast("a", 2).
+			Empty().Groups("create").
+			MaxLen(20).AsWarning().
+			Sensitive(),
+		"Tags": validators.NewSliceValidator[[]string](validators.NewStringValidator[string]().NotEmpty()).
+			MaxLen(3).
+			MaxErrors(2),
+		"Address": validators.NewPointerValidator(validators.MustStructValidator[Address](validators.StructShape{
+			"City": validators.NewStringValidator[string]().In("Berlin", "Paris"),
+		})).NotNil(),
+	}).NotZero()
+
+	want := `struct(notZero)
+  Address: pointer(notNil)
+    *: struct
+      City: string(in=("Berlin", "Paris"))
+  Name: string(containsAtLeast=("a", 2), empty [create], maxLen=20 [warning], sensitive)
+  Tags: slice(maxLen=3, maxErrors=2)
+    []: string(notEmpty)`
+	if got := v.String(); got != want {
+		t.Errorf("expected\n%s\ngot\n%s", want, got)
+	}
+
+	slice := validators.NewSliceValidator[[]int](validators.NewNumberValidator[int]()).
+		AnySatisfy(validators.NewNumberValidator[int]().GT(3))
+	if got, want := slice.String(), "slice(anySatisfy=number(gt=3))\n  []: number"; got != want {
+		t.Errorf("expected\n%s\ngot\n%s", want, got)
+	}
+}
@@ -0,0 +1,153 @@
+package validators
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// Codes reported for violations that do not originate from a named rule.
+const (
+	// CodeType is reported when ValidateAny receives a value of the wrong type.
+	CodeType = "type"
+	// CodeField is reported when a StructShape names a field that cannot be
+	// validated.
+	CodeField = "field"
+	// CodeCustom is reported for errors returned by validators outside of this
+	// package.
+	CodeCustom = "custom"
+	// CodeTruncated is reported in place of the violations beyond the limit
+	// set with MaxErrors.
+	CodeTruncated = "truncated"
+)
+
+// Error describes a single rule violation.
+//
+// Path locates the offending value relative to the validator that produced
+// the error, e.g. "Address.City" or "Tags[2]", and is empty when the root
+// value itself is invalid. Code identifies the rule that failed, e.g. "minLen".
+type Error struct {
+	Path string
+	Code string
+	// Message describes the violation. Validated values in it are
+	// truncated, see SetMaxValueLen.
+	Message string
+	Err     error
+	// Params and Value are the params of the rule that failed and the value
+	// it rejected, if known.
+	Params []any
+	Value  any
+
+	// format and args are those of Message, for redacting it.
+	format string
+	args   []any
+}
+
+func newError(code string, format string, args ...any) *Error {
+	return &Error{
+		Code:    code,
+		Message: fmt.Sprintf(format, args...),
+		format:  format,
+		args:    args,
+	}
+}
+
+func (e *Error) Error() string {
+	if e.Path == "" {
+		return e.Text()
+	}
+
+	return e.Path + ": " + e.Text()
+}
+
+// Text returns the message of e, without its path.
+func (e *Error) Text() string {
+	return e.Message
+}
+
+func (e *Error) Unwrap() error {
+	return e.Err
+}
+
+// Errors is returned by validators that check several values at once, such
+// as struct fields or slice elements, and holds one Error per violation.
+type Errors []*Error
+
+func (es Errors) Error() string {
+	msgs := make([]string, len(es))
+	for i, e := range es {
+		msgs[i] = e.Error()
+	}
+
+	return strings.Join(msgs, "; ")
+}
+
+func (es Errors) Unwrap() []error {
+	errs := make([]error, len(es))
+	for i, e := range es {
+		errs[i] = e
+	}
+
+	return errs
+}
+
+// AsErrors flattens err into the violations it carries. Errors that were not
+// produced by this package are reported as a single violation with CodeCustom.
+func AsErrors(err error) Errors {
+	var (
+		e  *Error
+		es Errors
+	)
+
+	switch {
+	case err == nil:
+		return nil
+	case errors.As(err, &es):
+		return es
+	case errors.As(err, &e):
+		return Errors{e}
+	default:
+		return Errors{{Code: CodeCustom, Message: err.Error(), Err: err}}
+	}
+}
+
+// withCode wraps errors returned by user supplied checks so they carry a code.
+func withCode(code string, err error) error {
+	var (
+		e  *Error
+		es Errors
+	)
+
+	if errors.As(err, &e) || errors.As(err, &es) {
+		return err
+	}
+
+	return &Error{Code: code, Message: err.Error(), Err: err}
+}
+
+// prefixPath returns err with every violation moved under the path segment
+// seg, which is either a field name or an index such as "[3]".
+func prefixPath(seg string, err error) Errors {
+	errs := AsErrors(err)
+	prefixed := make(Errors, len(errs))
+	for i, e := range errs {
+		c := *e
+		c.Path = joinPath(seg, e.Path)
+		prefixed[i] = &c
+	}
+
+	return prefixed
+}
+
+func joinPath(seg, path string) string {
+	switch {
+	case path == "":
+		return seg
+	case seg == "":
+		return path
+	case strings.HasPrefix(path, "["):
+		return seg + path
+	default:
+		return seg + "." + path
+	}
+}
@@ -0,0 +1,247 @@
+package validators
+
+import "slices"
+
+// Evaluation carries the settings of a single validation, such as the
+// requested validation groups, through nested validators, and collects the
+// warnings they report. A nil *Evaluation validates with the defaults and
+// discards warnings.
+type Evaluation struct {
+	// groups are the validation groups whose rules are checked in addition
+	// to those in no group.
+	groups []string
+	// warn is set if rules marked with AsWarning are checked, see Check.
+	warn     bool
+	warnings Errors
+	// observer is notified of validations and failures, see SetObserver.
+	observer Observer
+	// validator is the name of the validator being run, for observer.
+	validator string
+	// path holds the segments of the path to the value being validated.
+	path []string
+	// maxErrors is the limit set with MaxErrors, or 0.
+	maxErrors int
+	// trace is the explanation of the validator being run, see Explain.
+	trace *Explanation
+	// display is the value the next built-in validator run shows in place
+	// of the one it validates, see Converted.
+	display *display
+}
+
+// NewEvaluation returns an evaluation that checks the rules in no group or in
+// one of groups, and no warnings, and notifies the observer set with
+// SetObserver. It returns nil, which validates with the defaults, if there
+// are no groups and no observer.
+func NewEvaluation(groups ...string) *Evaluation {
+	o := loadObserver()
+	if len(groups) == 0 && o == nil {
+		return nil
+	}
+
+	return &Evaluation{groups: groups, observer: o}
+}
+
+// tracking returns ev, or an evaluation with the defaults if ev is nil, so
+// that the path to the value being validated is tracked.
+func tracking(ev *Evaluation) *Evaluation {
+	if ev == nil {
+		return &Evaluation{}
+	}
+
+	return ev
+}
+
+// Converted prepares ev for validating a conversion of value with a built-in
+// validator, such as a []any holding the elements of value, a []T: the next
+// built-in validator run in ev shows value in its errors in place of the
+// conversion, and is named by the type of value in explanations and observer
+// events. It returns ev, or a new evaluation if ev is nil. Adapters that
+// build validators for types only known at run time, like validconfig, use
+// it.
+func (ev *Evaluation) Converted(value any) *Evaluation {
+	if ev == nil {
+		ev = &Evaluation{}
+	}
+
+	ev.display = &display{value}
+
+	return ev
+}
+
+// probe returns an evaluation with the groups of ev for validating values
+// whose violations are not reported, such as the elements AnySatisfy tests.
+func (ev *Evaluation) probe() *Evaluation {
+	if ev == nil || len(ev.groups) == 0 {
+		return nil
+	}
+
+	return &Evaluation{groups: ev.groups}
+}
+
+// Checks reports whether r is checked in ev: it must be in no group or in one
+// of the requested groups, and if it is a warning, warnings must be
+// collected.
+func (ev *Evaluation) Checks(r Rule) bool {
+	if r.Warning && (ev == nil || !ev.warn) {
+		return false
+	}
+
+	if len(r.Groups) == 0 {
+		return true
+	}
+
+	if ev == nil {
+		return false
+	}
+
+	for _, g := range r.Groups {
+		if slices.Contains(ev.groups, g) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Report returns err, the violation of r, unless r is a warning, in which
+// case err is recorded as a warning and Report returns nil. Errors are passed
+// on to the observer.
+func (ev *Evaluation) Report(r Rule, err error) error {
+	return ev.report(r, err, true)
+}
+
+// report is like Report. Failures of rules that validate nested values are
+// not observed if leaf is unset, as the nested validators observe them.
+func (ev *Evaluation) report(r Rule, err error, leaf bool) error {
+	if err == nil || ev == nil {
+		return err
+	}
+
+	if r.Warning {
+		if ev.warn {
+			for _, e := range AsErrors(err) {
+				w := *e
+				w.Path = ev.pathTo(e.Path)
+				ev.warnings = append(ev.warnings, &w)
+			}
+		}
+
+		return nil
+	}
+
+	if ev.observer != nil && leaf {
+		for _, e := range AsErrors(err) {
+			ev.observer.Failed(FailureEvent{Validator: ev.validator, Path: ev.pathTo(e.Path), Code: e.Code})
+		}
+	}
+
+	return err
+}
+
+// enter descends into the value at seg, e.g. a field or element, until the
+// matching call to leave.
+func (ev *Evaluation) enter(seg string) {
+	if ev != nil {
+		ev.path = append(ev.path, seg)
+	}
+}
+
+func (ev *Evaluation) leave() {
+	if ev != nil {
+		ev.path = ev.path[:len(ev.path)-1]
+	}
+}
+
+// pathTo returns the path from the root value to path, a path relative to the
+// value being validated.
+func (ev *Evaluation) pathTo(path string) string {
+	root := ""
+	for _, seg := range ev.path {
+		root = joinPath(root, seg)
+	}
+
+	return joinPath(root, path)
+}
+
+// Result is the outcome of Check. Errors are the violations that make the
+// value invalid, and Warnings those of rules marked with AsWarning, which do
+// not.
+type Result struct {
+	Errors   Errors
+	Warnings Errors
+}
+
+// Err returns the errors of r, or nil if the value is valid.
+func (r Result) Err() error {
+	if len(r.Errors) == 0 {
+		return nil
+	}
+
+	return r.Errors
+}
+
+// Check validates value with v, like ValidateGroups, and additionally
+// checks the rules marked with AsWarning. Validators that do not take part in
+// evaluations, see EvaluationValidator, report no warnings.
+func Check(v AnyValidator, value any, groups ...string) Result {
+	ev := &Evaluation{groups: groups, warn: true, observer: loadObserver()}
+	err := validateAnyIn(ev, v, value)
+
+	return Result{Errors: AsErrors(err), Warnings: ev.warnings}
+}
+
+// GroupValidator is implemented by validators that support validation
+// groups.
+type GroupValidator interface {
+	ValidateAnyGroups(value any, groups ...string) error
+}
+
+// EvaluationValidator is implemented by validators that take part in the
+// evaluation they are nested in, honoring its groups and reporting warnings
+// to it. Validators outside of this package that wrap built-in validators
+// implement it to pass the evaluation on.
+type EvaluationValidator interface {
+	ValidateAnyIn(ev *Evaluation, value any) error
+}
+
+// evaluationValidator is the typed counterpart of EvaluationValidator
+// implemented by the built-in validators.
+type evaluationValidator[T any] interface {
+	validateIn(ev *Evaluation, value T) error
+}
+
+// validateIn validates value with v as part of ev.
+func validateIn[T any](ev *Evaluation, v Validator[T], value T) error {
+	if tv, ok := v.(evaluationValidator[T]); ok {
+		return tv.validateIn(ev, value)
+	}
+
+	if ev != nil {
+		if evv, ok := v.(EvaluationValidator); ok {
+			return evv.ValidateAnyIn(ev, value)
+		}
+
+		if gv, ok := v.(GroupValidator); ok && len(ev.groups) > 0 {
+			return gv.ValidateAnyGroups(value, ev.groups...)
+		}
+	}
+
+	return v.Validate(value)
+}
+
+// validateAnyIn is like validateIn for validators of unknown value types.
+func validateAnyIn(ev *Evaluation, v AnyValidator, value any) error {
+	if ev == nil {
+		return v.ValidateAny(value)
+	}
+
+	if evv, ok := v.(EvaluationValidator); ok {
+		return evv.ValidateAnyIn(ev, value)
+	}
+
+	if gv, ok := v.(GroupValidator); ok && len(ev.groups) > 0 {
+		return gv.ValidateAnyGroups(value, ev.groups...)
+	}
+
+	return v.ValidateAny(value)
+}
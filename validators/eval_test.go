@@ -0,0 +1,172 @@
+package validators_test
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/bitcrshr/valid/validators"
+	"github.com/bitcrshr/valid/validtest"
+)
+
+type DTO struct {
+	ID   string
+	Name string
+	Tags []string
+}
+
+func dtoValidator() *validators.StructValidator[DTO] {
+	return validators.MustStructValidator[DTO](validators.StructShape{
+		"ID": validators.NewStringValidator[string]().
+			Empty().Groups("create").
+			ValidUUID().Groups("update", "delete"),
+		"Name": validators.NewStringValidator[string]().NotEmpty(),
+		"Tags": validators.NewSliceValidator[[]string](
+			validators.NewStringValidator[string]().MaxLen(3).Groups("create"),
+		),
+	})
+}
+
+func TestValidateGroups(t *testing.T) {
+	v := dtoValidator()
+	id := "0e49b3e4-77ea-4c89-bdba-64a7d4efd042"
+
+	tests := []struct {
+		name   string
+		value  DTO
+		groups []string
+		want   []validtest.Violation
+	}{
+		{name: "default ignores groups", value: DTO{ID: "nope", Name: "a", Tags: []string{"long"}}},
+		{
+			name:  "default checks ungrouped rules",
+			value: DTO{},
+			want:  []validtest.Violation{{Path: "Name", Code: "notEmpty"}},
+		},
+		{name: "create", value: DTO{Name: "a", Tags: []string{"abc"}}, groups: []string{"create"}},
+		{
+			name:   "create rejects ID",
+			value:  DTO{ID: id, Name: "a", Tags: []string{"long"}},
+			groups: []string{"create"},
+			want: []validtest.Violation{
+				{Path: "ID", Code: "empty"},
+				{Path: "Tags[0]", Code: "maxLen"},
+			},
+		},
+		{name: "update", value: DTO{ID: id, Name: "a", Tags: []string{"long"}}, groups: []string{"update"}},
+		{
+			name:   "update requires ID",
+			value:  DTO{Name: "a"},
+			groups: []string{"update"},
+			want:   []validtest.Violation{{Path: "ID", Code: "uuid"}},
+		},
+		{
+			name:   "any of several groups",
+			value:  DTO{Name: "a"},
+			groups: []string{"delete", "archive"},
+			want:   []validtest.Violation{{Path: "ID", Code: "uuid"}},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got := validtest.Violations(v.ValidateGroups(test.value, test.groups...))
+			if !slices.Equal(got, test.want) {
+				t.Errorf("expected violations %v, got %v", test.want, got)
+			}
+		})
+	}
+}
+
+func TestGroupsRules(t *testing.T) {
+	base := validators.NewStringValidator[string]().Empty()
+	grouped := base.Groups("create").Groups("import")
+
+	if got := grouped.Rules()[0].Groups; !slices.Equal(got, []string{"create", "import"}) {
+		t.Errorf("expected groups [create import], got %v", got)
+	}
+
+	if got := base.Rules()[0].Groups; got != nil {
+		t.Errorf("expected Groups not to modify the base, got %v", got)
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Errorf("expected Groups without a rule to panic")
+		}
+	}()
+
+	validators.NewStringValidator[string]().Groups("create")
+}
+
+type Profile struct {
+	Name     string
+	Nickname string
+	Tags     []string
+}
+
+func TestCheckWarnings(t *testing.T) {
+	tag := validators.NewStringValidator[string]().MinLen(2).AsWarning()
+	v := validators.NewPointerValidator[Profile](validators.MustStructValidator[Profile](validators.StructShape{
+		"Name":     validators.NewStringValidator[string]().NotEmpty(),
+		"Nickname": validators.NewStringValidator[string]().MaxLen(5).AsWarning().NotEqualTo("admin"),
+		"Tags": validators.NewSliceValidator[[]string](tag).
+			MaxLen(3).AsWarning().
+			AnySatisfy(tag),
+	}))
+
+	tests := []struct {
+		name         string
+		value        *Profile
+		wantErrors   []validtest.Violation
+		wantWarnings []validtest.Violation
+	}{
+		{name: "clean", value: &Profile{Name: "a", Nickname: "bob", Tags: []string{"go"}}},
+		{
+			name:  "only warnings",
+			value: &Profile{Name: "a", Nickname: "bobby tables", Tags: []string{"go", "x", "rust", "zig"}},
+			wantWarnings: []validtest.Violation{
+				{Path: "Nickname", Code: "maxLen"},
+				{Path: "Tags", Code: "maxLen"},
+				{Path: "Tags[1]", Code: "minLen"},
+			},
+		},
+		{
+			name:         "errors and warnings",
+			value:        &Profile{Nickname: "admin", Tags: []string{"x", "go"}},
+			wantErrors:   []validtest.Violation{{Path: "Name", Code: "notEmpty"}, {Path: "Nickname", Code: "notEqualTo"}},
+			wantWarnings: []validtest.Violation{{Path: "Tags[0]", Code: "minLen"}},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if got := validtest.Violations(v.Validate(test.value)); !slices.Equal(got, test.wantErrors) {
+				t.Errorf("expected Validate to report %v, got %v", test.wantErrors, got)
+			}
+
+			res := v.Check(test.value)
+			if got := validtest.Violations(res.Err()); !slices.Equal(got, test.wantErrors) {
+				t.Errorf("expected errors %v, got %v", test.wantErrors, got)
+			}
+
+			if got := validtest.Violations(res.Warnings); !slices.Equal(got, test.wantWarnings) {
+				t.Errorf("expected warnings %v, got %v", test.wantWarnings, got)
+			}
+		})
+	}
+}
+
+func TestAsWarningRules(t *testing.T) {
+	base := validators.NewStringValidator[string]().MaxLen(3)
+	if !base.AsWarning().Rules()[0].Warning {
+		t.Errorf("expected AsWarning to mark the rule")
+	}
+
+	if base.Rules()[0].Warning {
+		t.Errorf("expected AsWarning not to modify the base")
+	}
+
+	if res := validators.Check(base.AsWarning(), "long"); res.Err() != nil || len(res.Warnings) != 1 {
+		t.Errorf("expected a single warning, got %+v", res)
+	}
+}
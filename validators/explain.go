@@ -0,0 +1,175 @@
+package validators
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// Explanation traces how a value was validated, see Explain: every rule of
+// the validator with its status, and the explanations of the validators that
+// were run for nested values, such as fields and elements.
+type Explanation struct {
+	// Validator names the validator by the type of values it validates, see
+	// ValidationEvent.
+	Validator string
+	// Path is the path to the validated value from the explained one.
+	Path  string
+	Rules []RuleTrace
+	// Nested explains the validators run for nested values, in the order
+	// they were run.
+	Nested []*Explanation
+	// Err is the result of the validator, with paths relative to the
+	// validated value.
+	Err error
+}
+
+// RuleTrace is the outcome of a rule in an Explanation.
+type RuleTrace struct {
+	Rule
+	Status RuleStatus
+	// Err is the violation of a failed rule.
+	Err error
+}
+
+// RuleStatus tells whether a rule passed, failed or was not checked.
+type RuleStatus int
+
+const (
+	RulePassed RuleStatus = iota
+	RuleFailed
+	// RuleSkipped is the status of rules outside of the requested groups,
+	// and of those after a failed rule.
+	RuleSkipped
+)
+
+func (s RuleStatus) String() string {
+	switch s {
+	case RulePassed:
+		return "passed"
+	case RuleFailed:
+		return "failed"
+	case RuleSkipped:
+		return "skipped"
+	}
+
+	return fmt.Sprintf("RuleStatus(%d)", int(s))
+}
+
+// Explain validates value with v like Check, and returns the trace of every
+// rule that was checked or skipped, of v and of the validators nested in it.
+// Validators that do not take part in evaluations, see EvaluationValidator,
+// are explained by their result only.
+func Explain(v AnyValidator, value any, groups ...string) *Explanation {
+	root := &Explanation{}
+	ev := &Evaluation{groups: groups, warn: true, trace: root}
+	err := validateAnyIn(ev, v, value)
+
+	if len(root.Nested) == 1 {
+		return root.Nested[0]
+	}
+
+	// v did not explain itself.
+	root.Err = err
+	if t := valueType(v); t != nil {
+		root.Validator = t.String()
+	}
+
+	return root
+}
+
+// Explain validates value like Check and returns the trace of every rule that
+// was checked or skipped, see Explain.
+func (v *baseValidator[T, Super]) Explain(value T, groups ...string) *Explanation {
+	return Explain(v.super, value, groups...)
+}
+
+// explainIn is like validateIn, and adds the explanation of the validation to
+// the trace of ev.
+func (v *baseValidator[T, Super]) explainIn(ev *Evaluation, value T, d *display) error {
+	parent := ev.trace
+	defer func() { ev.trace = parent }()
+
+	x := &Explanation{Validator: d.name(reflect.TypeFor[T]()), Path: ev.pathTo("")}
+	parent.Nested = append(parent.Nested, x)
+	ev.trace = x
+
+	// The rules are checked unfused, so that each is traced.
+	x.Err = d.show(checkRules(ev, v.rules, value, v.showsSensitive()), value)
+
+	// The rules after a failed one are not checked.
+	for _, r := range v.Rules()[len(x.Rules):] {
+		x.Rules = append(x.Rules, RuleTrace{Rule: r, Status: RuleSkipped})
+	}
+
+	return x.Err
+}
+
+// traceRule records the outcome of r in the trace of ev, if any. Unnamed
+// rules are not traced.
+func (ev *Evaluation) traceRule(r Rule, status RuleStatus, err error) {
+	if ev == nil || ev.trace == nil || r.Name == "" {
+		return
+	}
+
+	ev.trace.Rules = append(ev.trace.Rules, RuleTrace{Rule: r, Status: status, Err: err})
+}
+
+// String renders x as indented text, with a line for every validator and,
+// indented below it, a line for each of its rules, e.g.
+//
+//	main.User: failed
+//	  notZero: skipped
+//	  Name (string): failed
+//	    minLen(2): failed: expected `a` to have min len 2, but got 1
+//	    maxLen(20): skipped
+func (x *Explanation) String() string {
+	var b strings.Builder
+	x.write(&b, "")
+
+	return b.String()
+}
+
+func (x *Explanation) write(b *strings.Builder, indent string) {
+	b.WriteString(indent)
+	if x.Path != "" {
+		fmt.Fprintf(b, "%s (%s)", x.Path, x.Validator)
+	} else {
+		b.WriteString(x.Validator)
+	}
+
+	if x.Err != nil {
+		b.WriteString(": failed\n")
+	} else {
+		b.WriteString(": passed\n")
+	}
+
+	for _, r := range x.Rules {
+		b.WriteString(indent + "  " + r.Name)
+		if len(r.Params) > 0 {
+			ps := make([]string, len(r.Params))
+			for i, p := range r.Params {
+				ps[i] = formatParam(p)
+			}
+
+			b.WriteString("(" + strings.Join(ps, ", ") + ")")
+		}
+
+		b.WriteString(": " + r.Status.String())
+		if r.Warning {
+			b.WriteString(" (warning)")
+		}
+
+		// Violations of nested values are explained by the nested
+		// validators.
+		if e, ok := r.Err.(*Error); ok && e.Path == "" {
+			b.WriteString(": " + e.Text())
+		}
+
+		b.WriteString("\n")
+	}
+
+	for _, n := range x.Nested {
+		n.write(b, indent+"  ")
+	}
+}
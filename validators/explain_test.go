@@ -0,0 +1,55 @@
+package validators_test
+
+import (
+	"testing"
+
+	"github.com/bitcrshr/valid/validators"
+)
+
+func TestExplain(t *testing.T) {
+	type Address struct {
+		City string
+		Zip  string
+	}
+
+	v := validators.NewPointerValidator(
+		validators.MustStructValidator[Address](validators.StructShape{
+			"City": validators.NewStringValidator[string]().NotEmpty().MaxLen(20).AsWarning(),
+			"Zip":  validators.NewStringValidator[string]().Len(5).ValidUUID().Groups("strict"),
+		}).NotZero(),
+	).NotNil()
+
+	x := v.Explain(&Address{City: "Springfield", Zip: "123"})
+	if x.Err == nil || x.Validator != "*validators_test.Address" || len(x.Nested) != 1 {
+		t.Fatalf("expected the pointer to fail with its element nested, got:\n%v", x)
+	}
+
+	zip := x.Nested[0].Nested[1]
+	if zip.Path != "Zip" || len(zip.Rules) != 2 || zip.Rules[0].Status != validators.RuleFailed || zip.Rules[1].Status != validators.RuleSkipped {
+		t.Errorf("expected len to fail and uuid to be skipped, got:\n%v", zip)
+	}
+
+	// Nested values are validated before the rules added by the builder, so
+	// these are skipped once a field fails.
+	want := "*validators_test.Address: failed\n" +
+		"  notNil: skipped\n" +
+		"  validators_test.Address: failed\n" +
+		"    notZero: skipped\n" +
+		"    City (string): passed\n" +
+		"      notEmpty: passed\n" +
+		"      maxLen(20): passed (warning)\n" +
+		"    Zip (string): failed\n" +
+		"      len(5): failed: expected `123` to have len 5, but got 3\n" +
+		"      uuid: skipped\n"
+	if got := x.String(); got != want {
+		t.Errorf("expected explanation\n%s\ngot\n%s", want, got)
+	}
+
+	if x := v.Explain(&Address{City: "Springfield", Zip: "12345"}, "strict"); x.Err == nil || x.Nested[0].Nested[1].Rules[1].Status != validators.RuleFailed {
+		t.Errorf("expected uuid to fail in the strict group, got:\n%v", x)
+	}
+
+	if x := validators.Explain(validators.NewStringValidator[string]().NotEmpty(), 42); x.Err == nil || len(x.Rules) != 0 {
+		t.Errorf("expected a value of the wrong type to fail without rules, got:\n%v", x)
+	}
+}
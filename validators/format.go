@@ -0,0 +1,272 @@
+package validators
+
+import (
+	"fmt"
+	"io"
+	"reflect"
+	"strings"
+	"sync/atomic"
+	"unicode/utf8"
+)
+
+// defaultMaxValueLen is the default of SetMaxValueLen.
+const defaultMaxValueLen = 100
+
+var maxValueLen atomic.Int64
+
+func init() {
+	maxValueLen.Store(defaultMaxValueLen)
+}
+
+// SetMaxValueLen sets the number of bytes of a validated value that are shown
+// in error messages, 100 by default, which also bounds lists of params such
+// as those of In. Longer values are truncated, and slices and arrays are
+// formatted only up to the element that exceeds the limit, so that large
+// inputs cannot blow up the size of errors. If n is 0 or less, values are
+// shown in full.
+func SetMaxValueLen(n int) {
+	maxValueLen.Store(int64(n))
+}
+
+// MaxErrors limits the number of violations v reports, including those of
+// validators nested in it, to n. Struct and slice validators stop validating
+// fields and elements once they have found more than n violations, and report
+// a violation with CodeTruncated in place of those past n. A limit set on an
+// enclosing validator takes precedence. If n is 0 or less, all violations are
+// reported, which is the default.
+func (v *baseValidator[T, Super]) MaxErrors(n int) Super {
+	v.mustNotBeSealed("maxErrors")
+
+	return v.deriveWith(func(next *baseValidator[T, Super]) {
+		next.maxErrors = n
+	})
+}
+
+// limitIn applies the limit set with MaxErrors to ev, unless an enclosing
+// validator already set one, until done is called.
+func (v *baseValidator[T, Super]) limitIn(ev *Evaluation) (_ *Evaluation, done func()) {
+	if v.maxErrors <= 0 || (ev != nil && ev.maxErrors > 0) {
+		return ev, func() {}
+	}
+
+	if ev == nil {
+		ev = &Evaluation{}
+	}
+
+	ev.maxErrors = v.maxErrors
+
+	return ev, func() { ev.maxErrors = 0 }
+}
+
+// limit reports whether errs, the violations collected so far by a struct or
+// slice validator, exceed the limit set with MaxErrors. If so, it returns
+// them cut to the limit and followed by a violation with CodeTruncated.
+func (ev *Evaluation) limit(errs Errors) (Errors, bool) {
+	if ev == nil || ev.maxErrors <= 0 || len(errs) <= ev.maxErrors {
+		return errs, false
+	}
+
+	truncated := newError(CodeTruncated, "further violations omitted after %d", ev.maxErrors)
+
+	return append(errs[:ev.maxErrors:ev.maxErrors], truncated), true
+}
+
+// inputArg marks an argument of an error message as the validated value, or
+// a part of it, so that it can be truncated and redacted. It formats like the
+// value.
+type inputArg struct {
+	value any
+}
+
+func input(value any) inputArg {
+	return inputArg{value}
+}
+
+func (a inputArg) Format(f fmt.State, verb rune) {
+	formatBounded(f, verb, a.value)
+}
+
+// boundedArg marks a param of a rule shown in its message that may be as long
+// as a validated value, such as the haystack of In, or show it when the rule
+// fails, such as that of NotEqualTo. It is truncated like the value and
+// redacted along with it.
+type boundedArg struct {
+	value any
+}
+
+func bounded(value any) boundedArg {
+	return boundedArg{value}
+}
+
+func (a boundedArg) Format(f fmt.State, verb rune) {
+	formatBounded(f, verb, a.value)
+}
+
+// formatBounded formats value to f, truncated to the limit set with
+// SetMaxValueLen.
+func formatBounded(f fmt.State, verb rune, value any) {
+	format := fmt.FormatString(f, verb)
+	if limit := int(maxValueLen.Load()); limit > 0 {
+		_, _ = io.WriteString(f, formatValue(value, format, limit))
+		return
+	}
+
+	fmt.Fprintf(f, format, value)
+}
+
+// display is the value a validator shows in place of the conversion of it
+// that it validates, see Evaluation.Converted. A nil *display shows the
+// validated value itself.
+type display struct {
+	value any
+}
+
+// name returns the name of a validator of values of type t: the type of the
+// value shown in their place, if any.
+func (d *display) name(t reflect.Type) string {
+	if d == nil {
+		return t.String()
+	}
+
+	return fmt.Sprintf("%T", d.value)
+}
+
+// show returns err, the result of validating value, with the value shown by
+// d in place of value in its violations of value itself. Violations of
+// nested values, with a path, show values that were not converted.
+func (d *display) show(err error, value any) error {
+	if d == nil || err == nil {
+		return err
+	}
+
+	switch e := err.(type) {
+	case *Error:
+		return d.showIn(e, value)
+	case Errors:
+		shown := make(Errors, len(e))
+		for i, e := range e {
+			shown[i] = d.showIn(e, value)
+		}
+
+		return shown
+	}
+
+	return err
+}
+
+func (d *display) showIn(e *Error, value any) *Error {
+	if e.Path != "" {
+		return e
+	}
+
+	c := *e
+	if sameValue(c.Value, value) {
+		c.Value = d.value
+	}
+
+	if c.format != "" {
+		c.args = make([]any, len(e.args))
+		for i, arg := range e.args {
+			c.args[i] = arg
+			if in, ok := arg.(inputArg); ok && sameValue(in.value, value) {
+				c.args[i] = input(d.value)
+			}
+		}
+
+		c.Message = fmt.Sprintf(c.format, c.args...)
+	}
+
+	return &c
+}
+
+// sameValue reports whether a and b are the same value, or, for pointers,
+// slices and maps, refer to the same data, as the conversions of a value and
+// the arguments of messages about it do.
+func sameValue(a, b any) bool {
+	ra, rb := reflect.ValueOf(a), reflect.ValueOf(b)
+	if !ra.IsValid() || !rb.IsValid() || ra.Type() != rb.Type() {
+		return false
+	}
+
+	switch ra.Kind() {
+	case reflect.Pointer, reflect.Map:
+		return ra.Pointer() == rb.Pointer()
+	case reflect.Slice:
+		return ra.Pointer() == rb.Pointer() && ra.Len() == rb.Len()
+	}
+
+	return ra.Comparable() && ra.Equal(rb)
+}
+
+// formatValue formats value like fmt.Sprintf(format, value), but truncated to
+// about limit bytes, without formatting much more than that.
+func formatValue(value any, format string, limit int) string {
+	rv := reflect.ValueOf(value)
+
+	var s string
+	switch rv.Kind() {
+	case reflect.String:
+		if rv.Len() > limit {
+			str := reflect.ValueOf(cut(rv.String(), limit)).Convert(rv.Type())
+			return fmt.Sprintf(format, str.Interface()) + "…"
+		}
+
+		s = fmt.Sprintf(format, value)
+	case reflect.Slice, reflect.Array:
+		if rv.Kind() == reflect.Slice && rv.Type().Elem().Kind() == reflect.Uint8 {
+			// Byte slices may be formatted as strings.
+			if rv.Len() > limit {
+				return fmt.Sprintf(format, rv.Slice(0, limit).Interface()) + "…"
+			}
+
+			return fmt.Sprintf(format, value)
+		}
+
+		var b strings.Builder
+		b.WriteByte('[')
+		for i := range rv.Len() {
+			if b.Len() > limit {
+				fmt.Fprintf(&b, "… (%d elements)", rv.Len())
+				break
+			}
+
+			if i > 0 {
+				b.WriteByte(' ')
+			}
+
+			b.WriteString(formatValue(rv.Index(i).Interface(), format, max(limit-b.Len(), 0)))
+		}
+		b.WriteByte(']')
+
+		return b.String()
+	case reflect.Map:
+		// Maps are formatted with sorted keys, which requires all of them.
+		if rv.Len() > limit {
+			return fmt.Sprintf("map[… (%d entries)]", rv.Len())
+		}
+
+		s = fmt.Sprintf(format, value)
+	default:
+		s = fmt.Sprintf(format, value)
+	}
+
+	if len(s) > limit {
+		return cut(s, limit) + "…"
+	}
+
+	return s
+}
+
+// cut returns the longest prefix of s of at most n bytes that does not end
+// in the middle of a rune.
+func cut(s string, n int) string {
+	if n >= len(s) {
+		return s
+	}
+
+	for n > 0 && !utf8.RuneStart(s[n]) {
+		n--
+	}
+
+	return s[:n]
+}
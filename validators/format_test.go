@@ -0,0 +1,133 @@
+package validators_test
+
+import (
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/bitcrshr/valid/validators"
+	"github.com/bitcrshr/valid/validtest"
+)
+
+func TestBoundedMessages(t *testing.T) {
+	huge := make([]string, 100_000)
+	for i := range huge {
+		huge[i] = "element"
+	}
+
+	numbers := make([]int, 100_000)
+	for i := range numbers {
+		numbers[i] = i
+	}
+
+	tests := []struct {
+		name  string
+		err   error
+		start string
+	}{
+		{
+			name:  "slice",
+			err:   validators.NewSliceValidator[[]string](validators.NewStringValidator[string]()).Empty().Validate(huge),
+			start: "expected [element element",
+		},
+		{
+			name:  "map",
+			err:   validators.NewMapValidator[int, bool]().Empty().Validate(map[int]bool{}),
+			start: "",
+		},
+		{
+			name:  "in",
+			err:   validators.NewStringValidator[string]().In(huge...).Validate("x"),
+			start: "expected `x` to be in ([\"element\" \"element\"",
+		},
+		{
+			name:  "notIn",
+			err:   validators.NewNumberValidator[int]().NotIn(numbers...).Validate(1),
+			start: "expected 1 not to be in ([0 1 2",
+		},
+		{
+			name:  "string",
+			err:   validators.NewStringValidator[string]().MaxLen(10).Validate(strings.Repeat("ab", 100_000)),
+			start: "expected `ababab",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if test.start == "" {
+				if test.err != nil {
+					t.Fatalf("expected no error, got %v", test.err)
+				}
+
+				return
+			}
+
+			msg := test.err.Error()
+			if !strings.HasPrefix(msg, test.start) || len(msg) > 200 {
+				t.Errorf("expected a truncated message starting with %q, got %d bytes: %q", test.start, len(msg), msg)
+			}
+		})
+	}
+
+	big := make(map[int]bool, 1000)
+	for i := range 1000 {
+		big[i] = true
+	}
+
+	if msg := validators.NewMapValidator[int, bool]().Empty().Validate(big).Error(); msg != "expected map[… (1000 entries)] to be empty" {
+		t.Errorf("expected large maps to be elided, got %q", msg)
+	}
+
+	if msg := validators.NewStringValidator[string]().In("a", "b").Validate("c").Error(); msg != "expected `c` to be in ([\"a\" \"b\"])" {
+		t.Errorf("expected short lists in full, got %q", msg)
+	}
+}
+
+func TestMessages(t *testing.T) {
+	err := validators.NewStringValidator[string]().MinLen(3).Validate("ab")
+
+	e := validators.AsErrors(err)[0]
+	if want := "expected `ab` to have min len 3, but got 2"; e.Message != want || e.Text() != want {
+		t.Errorf("expected Message and Text to be %q, got %q and %q", want, e.Message, e.Text())
+	}
+
+	validators.SetMaxValueLen(0)
+	defer validators.SetMaxValueLen(100)
+
+	long := strings.Repeat("a", 500)
+	if err := validators.NewStringValidator[string]().MaxLen(10).Validate(long); !strings.Contains(err.Error(), long) {
+		t.Errorf("expected the value in full, got %q", err)
+	}
+}
+
+func TestMaxErrors(t *testing.T) {
+	elems := validators.NewSliceValidator[[]string](validators.NewStringValidator[string]().NotEmpty())
+	values := make([]string, 100_000)
+
+	got := validtest.Violations(elems.MaxErrors(3).Validate(values))
+	want := []validtest.Violation{{Code: "truncated"}, {Path: "[0]", Code: "notEmpty"}, {Path: "[1]", Code: "notEmpty"}, {Path: "[2]", Code: "notEmpty"}}
+	if !slices.Equal(got, want) {
+		t.Errorf("expected %v, got %v", want, got)
+	}
+
+	got = validtest.Violations(elems.MaxErrors(3).Validate(values[:3]))
+	want = []validtest.Violation{{Path: "[0]", Code: "notEmpty"}, {Path: "[1]", Code: "notEmpty"}, {Path: "[2]", Code: "notEmpty"}}
+	if !slices.Equal(got, want) {
+		t.Errorf("expected exactly MaxErrors violations not to be truncated, got %v", got)
+	}
+
+	if n := len(validators.AsErrors(elems.Validate(values))); n != len(values) {
+		t.Errorf("expected MaxErrors not to modify the base, got %d violations", n)
+	}
+
+	v := validators.MustStructValidator[Profile](validators.StructShape{
+		"Name": validators.NewStringValidator[string]().NotEmpty(),
+		"Tags": elems,
+	}).MaxErrors(2)
+
+	got = validtest.Violations(v.Validate(Profile{Tags: values}))
+	want = []validtest.Violation{{Code: "truncated"}, {Path: "Name", Code: "notEmpty"}, {Path: "Tags[0]", Code: "notEmpty"}}
+	if !slices.Equal(got, want) {
+		t.Errorf("expected the limit to apply to nested validators, got %v", got)
+	}
+}
@@ -0,0 +1,61 @@
+package validators
+
+import (
+	"log/slog"
+	"strconv"
+)
+
+const (
+	// maxLogLen is the number of bytes of values and params that are logged.
+	maxLogLen = 64
+	// maxLogErrors is the number of violations of Errors that are logged.
+	maxLogErrors = 10
+)
+
+// LogValue logs e as a group of its path, code, params and value, leaving
+// out the message, which may hold the complete value. Params and value are
+// formatted with %v and truncated.
+func (e *Error) LogValue() slog.Value {
+	attrs := make([]slog.Attr, 0, 4)
+	if e.Path != "" {
+		attrs = append(attrs, slog.String("path", e.Path))
+	}
+
+	attrs = append(attrs, slog.String("code", e.Code))
+
+	if len(e.Params) > 0 {
+		attrs = append(attrs, slog.String("params", formatValue(e.Params, "%v", maxLogLen)))
+	}
+
+	if e.Value != nil {
+		attrs = append(attrs, slog.String("value", formatValue(e.Value, "%v", maxLogLen)))
+	}
+
+	return slog.GroupValue(attrs...)
+}
+
+// LogValue logs es as a group of the number of violations and the first of
+// them, keyed by their index.
+func (es Errors) LogValue() slog.Value {
+	attrs := make([]slog.Attr, 0, min(len(es), maxLogErrors)+1)
+	attrs = append(attrs, slog.Int("count", len(es)))
+	for i, e := range es[:min(len(es), maxLogErrors)] {
+		attrs = append(attrs, slog.Any(strconv.Itoa(i), e))
+	}
+
+	return slog.GroupValue(attrs...)
+}
+
+// LogAttrs returns attributes describing err for structured logging, e.g.
+// with slog.Logger.LogAttrs. Violations are logged compactly under
+// "violations", see Errors.LogValue; other errors are logged as "error".
+func LogAttrs(err error) []slog.Attr {
+	switch errs := AsErrors(err); {
+	case err == nil:
+		return nil
+	case len(errs) == 1 && errs[0].Code == CodeCustom:
+		return []slog.Attr{slog.String("error", err.Error())}
+	default:
+		return []slog.Attr{slog.Any("violations", errs)}
+	}
+}
@@ -0,0 +1,74 @@
+package validators_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"strings"
+	"testing"
+
+	"github.com/bitcrshr/valid/validators"
+)
+
+func logJSON(t *testing.T, attrs ...slog.Attr) map[string]any {
+	t.Helper()
+
+	var buf bytes.Buffer
+	slog.New(slog.NewJSONHandler(&buf, nil)).LogAttrs(t.Context(), slog.LevelInfo, "invalid", attrs...)
+
+	var out map[string]any
+	if err := json.Unmarshal(buf.Bytes(), &out); err != nil {
+		t.Fatal(err)
+	}
+
+	return out
+}
+
+func TestErrorLogValue(t *testing.T) {
+	long := make([]string, 1000)
+	for i := range long {
+		long[i] = "tag"
+	}
+
+	err := validators.NewSliceValidator[[]string](validators.NewStringValidator[string]()).MaxLen(10).Validate(long)
+
+	out := logJSON(t, slog.Any("err", err))
+	got, _ := out["err"].(map[string]any)
+	if got["code"] != "maxLen" || got["params"] != "[10]" {
+		t.Errorf("expected code maxLen and params [10], got %v", got)
+	}
+
+	if value, _ := got["value"].(string); !strings.HasPrefix(value, "[tag tag") || len(value) > 100 {
+		t.Errorf("expected a truncated value, got %q", value)
+	}
+
+	if _, ok := got["path"]; ok {
+		t.Errorf("expected no path for the root value, got %v", got)
+	}
+}
+
+func TestLogAttrs(t *testing.T) {
+	v := validators.MustStructValidator[Profile](validators.StructShape{
+		"Name": validators.NewStringValidator[string]().NotEmpty(),
+		"Tags": validators.NewSliceValidator[[]string](validators.NewStringValidator[string]().MinLen(2)),
+	})
+
+	out := logJSON(t, validators.LogAttrs(v.Validate(Profile{Tags: []string{"go", "x"}}))...)
+	got, _ := out["violations"].(map[string]any)
+	if got["count"] != 2.0 {
+		t.Fatalf("expected 2 violations, got %v", out)
+	}
+
+	if second, _ := got["1"].(map[string]any); second["path"] != "Tags[1]" || second["code"] != "minLen" || second["value"] != "x" {
+		t.Errorf("expected Tags[1] to fail minLen with value x, got %v", second)
+	}
+
+	if out := logJSON(t, validators.LogAttrs(errors.New("boom"))...); out["error"] != "boom" {
+		t.Errorf("expected other errors to be logged as error, got %v", out)
+	}
+
+	if attrs := validators.LogAttrs(nil); attrs != nil {
+		t.Errorf("expected no attributes for nil, got %v", attrs)
+	}
+}
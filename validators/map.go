@@ -1,7 +1,5 @@
 package validators
 
-import "fmt"
-
 type mapValidator[K comparable, V any] struct {
 	*baseValidator[map[K]V, MapValidator[K, V]]
 }
@@ -9,74 +7,73 @@ type mapValidator[K comparable, V any] struct {
 func NewMapValidator[K comparable, V any]() MapValidator[K, V] {
 	v := &mapValidator[K, V]{}
 
-	v.baseValidator = newBaseValidator[map[K]V, MapValidator[K, V]](v)
+	v.baseValidator = newBaseValidator[map[K]V, MapValidator[K, V]](v, v.clone)
 
 	return v
 }
 
+func (v *mapValidator[K, V]) clone(base *baseValidator[map[K]V, MapValidator[K, V]]) MapValidator[K, V] {
+	c := *v
+	c.baseValidator = base
+
+	return &c
+}
+
 func (v *mapValidator[K, V]) Empty() MapValidator[K, V] {
-	v.checks = append(
-		v.checks,
+	return v.with(
+		Rule{Name: "empty"},
 		func(m map[K]V) error {
 			if len(m) > 0 {
-				return fmt.Errorf("expected %v to be empty", m)
+				return newError("empty", "expected %v to be empty", input(m))
 			}
 
 			return nil
 		},
 	)
-
-	return v
 }
 
 func (v *mapValidator[K, V]) NotEmpty() MapValidator[K, V] {
-	v.checks = append(
-		v.checks,
+	return v.with(
+		Rule{Name: "notEmpty"},
 		func(m map[K]V) error {
 			if len(m) == 0 {
-				return fmt.Errorf("expected %v not to be empty", m)
+				return newError("notEmpty", "expected %v not to be empty", input(m))
 			}
 
 			return nil
 		},
 	)
-
-	return v
 }
 
 func (v *mapValidator[K, V]) HasKey(key K) MapValidator[K, V] {
-	v.checks = append(
-		v.checks,
+	return v.with(
+		Rule{Name: "hasKey", Params: []any{key}},
 		func(m map[K]V) error {
 			if _, ok := m[key]; !ok {
-				return fmt.Errorf("expected %v to have key %v", m, key)
+				return newError("hasKey", "expected %v to have key %v", input(m), key)
 			}
 
 			return nil
 		},
 	)
-
-	return v
 }
 
 func (v *mapValidator[K, V]) NotHasKey(key K) MapValidator[K, V] {
-	v.checks = append(
-		v.checks,
+	return v.with(
+		Rule{Name: "notHasKey", Params: []any{key}},
 		func(m map[K]V) error {
 			if _, ok := m[key]; ok {
-				return fmt.Errorf("expected %v not to have key %v", m, key)
+				return newError("notHasKey", "expected %v not to have key %v", input(m), bounded(key))
 			}
 
 			return nil
 		},
 	)
-
-	return v
 }
 
 func (v *mapValidator[K, V]) HasKeyIn(haystack ...K) MapValidator[K, V] {
-	v.checks = append(
-		v.checks,
+	return v.with(
+		Rule{Name: "hasKeyIn", Params: params(haystack)},
 		func(m map[K]V) error {
 			for _, needle := range haystack {
 				if _, ok := m[needle]; ok {
@@ -84,26 +81,26 @@ func (v *mapValidator[K, V]) HasKeyIn(haystack ...K) MapValidator[K, V] {
 				}
 			}
 
-			return fmt.Errorf("expected %v to have at least 1 key in (%v)", m, haystack)
+			return newError("hasKeyIn", "expected %v to have at least 1 key in (%v)", input(m), bounded(haystack))
 		},
 	)
-
-	return v
 }
 
 func (v *mapValidator[K, V]) NotHasKeyIn(haystack ...K) MapValidator[K, V] {
-	v.checks = append(
-		v.checks,
+	return v.with(
+		Rule{Name: "notHasKeyIn", Params: params(haystack)},
 		func(m map[K]V) error {
 			for _, needle := range haystack {
 				if _, ok := m[needle]; ok {
-					return fmt.Errorf("expected %v not to have any keys in (%v)", m, haystack)
+					return newError("notHasKeyIn", "expected %v not to have any keys in (%v)", input(m), bounded(haystack))
 				}
 			}
 
 			return nil
 		},
 	)
+}
 
-	return v
+func (v *mapValidator[K, V]) Describe() Description {
+	return v.describe("map")
 }
@@ -1,8 +1,7 @@
 package validators
 
 import (
-	"fmt"
-	"slices"
+	"reflect"
 
 	"golang.org/x/exp/constraints"
 )
@@ -13,189 +12,184 @@ type numberValidator[T constraints.Integer | constraints.Float] struct {
 
 func NewNumberValidator[T constraints.Integer | constraints.Float]() NumberValidator[T] {
 	v := &numberValidator[T]{}
-	v.baseValidator = newBaseValidator[T, NumberValidator[T]](v)
+	v.baseValidator = newBaseValidator[T, NumberValidator[T]](v, v.clone)
 
 	return v
 }
 
+func (v *numberValidator[T]) clone(base *baseValidator[T, NumberValidator[T]]) NumberValidator[T] {
+	c := *v
+	c.baseValidator = base
+
+	return &c
+}
+
 var _ NumberValidator[int] = NewNumberValidator[int]()
 
 func (v *numberValidator[T]) Positive() NumberValidator[T] {
-	v.checks = append(
-		v.checks,
+	return v.with(
+		Rule{Name: "positive"},
 		func(t T) error {
 			if t < 0 {
-				return fmt.Errorf("expected %v to be positive", t)
+				return newError("positive", "expected %v to be positive", input(t))
 			}
 
 			return nil
 		},
 	)
-
-	return v
 }
 
 func (v *numberValidator[T]) Negative() NumberValidator[T] {
-	v.checks = append(
-		v.checks,
+	return v.with(
+		Rule{Name: "negative"},
 		func(t T) error {
 			if t > 0 {
-				return fmt.Errorf("expected %v to be negative", t)
+				return newError("negative", "expected %v to be negative", input(t))
 			}
 
 			return nil
 		},
 	)
-
-	return v
 }
 
 func (v *numberValidator[T]) Zero() NumberValidator[T] {
-	v.checks = append(
-		v.checks,
+	return v.with(
+		Rule{Name: "zero"},
 		func(t T) error {
 			if t != 0 {
-				return fmt.Errorf("expected %v to be zero", t)
+				return newError("zero", "expected %v to be zero", input(t))
 			}
 
 			return nil
 		},
 	)
-
-	return v
 }
 
 func (v *numberValidator[T]) NonZero() NumberValidator[T] {
-	v.checks = append(
-		v.checks,
+	return v.with(
+		Rule{Name: "nonZero"},
 		func(t T) error {
 			if t == 0 {
-				return fmt.Errorf("expected %v to be nonzero", t)
+				return newError("nonZero", "expected %v to be nonzero", input(t))
 			}
 
 			return nil
 		},
 	)
-
-	return v
 }
 
 func (v *numberValidator[T]) LT(upper T) NumberValidator[T] {
-	v.checks = append(
-		v.checks,
+	return v.with(
+		Rule{Name: "lt", Params: []any{upper}},
 		func(t T) error {
 			if t >= upper {
-				return fmt.Errorf("expected %v to be less than %v", t, upper)
+				return newError("lt", "expected %v to be less than %v", input(t), upper)
 			}
 
 			return nil
 		},
 	)
-
-	return v
 }
 
 func (v *numberValidator[T]) LTE(upper T) NumberValidator[T] {
-	v.checks = append(
-		v.checks,
+	return v.with(
+		Rule{Name: "lte", Params: []any{upper}},
 		func(t T) error {
 			if t > upper {
-				return fmt.Errorf("expected %v to be less than or equal to %v", t, upper)
+				return newError("lte", "expected %v to be less than or equal to %v", input(t), upper)
 			}
 
 			return nil
 		},
 	)
-
-	return v
 }
 
 func (v *numberValidator[T]) GT(lower T) NumberValidator[T] {
-	v.checks = append(
-		v.checks,
+	return v.with(
+		Rule{Name: "gt", Params: []any{lower}},
 		func(t T) error {
 			if t <= lower {
-				return fmt.Errorf("expected %v to be greater than %v", t, lower)
+				return newError("gt", "expected %v to be greater than %v", input(t), lower)
 			}
 
 			return nil
 		},
 	)
-
-	return v
 }
 
 func (v *numberValidator[T]) GTE(lower T) NumberValidator[T] {
-	v.checks = append(
-		v.checks,
+	return v.with(
+		Rule{Name: "gte", Params: []any{lower}},
 		func(t T) error {
 			if t < lower {
-				return fmt.Errorf("expected %v to be greater than or equal to %v", t, lower)
+				return newError("gte", "expected %v to be greater than or equal to %v", input(t), lower)
 			}
 
 			return nil
 		},
 	)
-
-	return v
 }
 
 func (v *numberValidator[T]) EqualTo(other T) NumberValidator[T] {
-	v.checks = append(
-		v.checks,
+	return v.with(
+		Rule{Name: "equalTo", Params: []any{other}},
 		func(t T) error {
 			if t != other {
-				return fmt.Errorf("expected %v to be equal to %v", t, other)
+				return newError("equalTo", "expected %v to be equal to %v", input(t), other)
 			}
 
 			return nil
 		},
 	)
-
-	return v
 }
 
 func (v *numberValidator[T]) NotEqualTo(other T) NumberValidator[T] {
-	v.checks = append(
-		v.checks,
+	return v.with(
+		Rule{Name: "notEqualTo", Params: []any{other}},
 		func(t T) error {
 			if t == other {
-				return fmt.Errorf("expected %v not to be equal to %v", t, other)
+				return newError("notEqualTo", "expected %v not to be equal to %v", input(t), bounded(other))
 			}
 
 			return nil
 		},
 	)
-
-	return v
 }
 
 func (v *numberValidator[T]) In(haystack ...T) NumberValidator[T] {
-	v.checks = append(
-		v.checks,
+	in := contains(haystack)
+
+	return v.with(
+		Rule{Name: "in", Params: params(haystack)},
 		func(t T) error {
-			if !slices.Contains(haystack, t) {
-				return fmt.Errorf("expected %v to be in (%v)", t, haystack)
+			if !in(t) {
+				return newError("in", "expected %v to be in (%v)", input(t), bounded(haystack))
 			}
 
 			return nil
 		},
 	)
-
-	return v
 }
 
 func (v *numberValidator[T]) NotIn(haystack ...T) NumberValidator[T] {
-	v.checks = append(
-		v.checks,
+	in := contains(haystack)
+
+	return v.with(
+		Rule{Name: "notIn", Params: params(haystack)},
 		func(t T) error {
-			if slices.Contains(haystack, t) {
-				return fmt.Errorf("expected %v not to be in (%v)", t, haystack)
+			if in(t) {
+				return newError("notIn", "expected %v not to be in (%v)", input(t), bounded(haystack))
 			}
 
 			return nil
 		},
 	)
+}
 
-	return v
+func (v *numberValidator[T]) Describe() Description {
+	d := v.describe("number")
+	k := reflect.TypeFor[T]().Kind()
+	d.Integer = k != reflect.Float32 && k != reflect.Float64
+
+	return d
 }
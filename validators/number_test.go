@@ -184,6 +184,29 @@ func TestNumberValidatorInt(t *testing.T) {
 			},
 		},
 
+		{
+			// Large haystacks are looked up in a set.
+			v: validators.NewNumberValidator[int]().In(1, 2, 3, 5, 8, 13, 21, 34, 55, 89, math.MinInt),
+			cases: []numTestCase[int]{
+				{num: 5, pass: true},
+				{num: 89, pass: true},
+				{num: 0, pass: false},
+				{num: 4, pass: false},
+				{num: math.MaxInt, pass: false},
+				{num: math.MinInt, pass: true},
+			},
+		},
+
+		{
+			v: validators.NewNumberValidator[int]().NotIn(1, 2, 3, 5, 8, 13, 21, 34, 55, 89),
+			cases: []numTestCase[int]{
+				{num: 5, pass: false},
+				{num: 89, pass: false},
+				{num: 0, pass: true},
+				{num: 4, pass: true},
+			},
+		},
+
 		{
 			v: validators.NewNumberValidator[int]().NotIn(),
 			cases: []numTestCase[int]{
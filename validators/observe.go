@@ -0,0 +1,70 @@
+package validators
+
+import (
+	"sync/atomic"
+	"time"
+)
+
+// Observer is notified of every validation run by a built-in validator,
+// including those of validators nested in others, and of every rule that
+// fails, e.g. to count which rules reject the most values. Its methods may be
+// called from several goroutines at once and should return quickly.
+type Observer interface {
+	Validated(e ValidationEvent)
+	Failed(e FailureEvent)
+}
+
+// ValidationEvent describes a single run of a validator.
+type ValidationEvent struct {
+	// Validator names the validator by the type of values it validates, e.g.
+	// "string" or "main.User".
+	Validator string
+	// Path is the path to the validated value from the value validation
+	// started with, e.g. "Address.City" or "" for the latter.
+	Path     string
+	Duration time.Duration
+	// Err is the result of the validator, with paths relative to the
+	// validated value.
+	Err error
+}
+
+// FailureEvent describes a violation of a rule. Warnings are not reported.
+type FailureEvent struct {
+	// Validator names the validator of the rule, see ValidationEvent.
+	Validator string
+	// Path is the path to the offending value from the value validation
+	// started with.
+	Path string
+	Code string
+}
+
+// NopObserver is the default Observer, which ignores all events.
+type NopObserver struct{}
+
+func (NopObserver) Validated(ValidationEvent) {}
+
+func (NopObserver) Failed(FailureEvent) {}
+
+var observer atomic.Pointer[Observer]
+
+// SetObserver makes o the observer of all validations that start after it
+// returns. Passing nil restores NopObserver. Validations are not timed while
+// NopObserver is set, so it costs nothing.
+func SetObserver(o Observer) {
+	if _, nop := o.(NopObserver); nop || o == nil {
+		observer.Store(nil)
+		return
+	}
+
+	observer.Store(&o)
+}
+
+// loadObserver returns the observer set with SetObserver, or nil for
+// NopObserver.
+func loadObserver() Observer {
+	if o := observer.Load(); o != nil {
+		return *o
+	}
+
+	return nil
+}
@@ -0,0 +1,86 @@
+package validators_test
+
+import (
+	"slices"
+	"sync"
+	"testing"
+
+	"github.com/bitcrshr/valid/validators"
+)
+
+type observer struct {
+	mu          sync.Mutex
+	validations []validators.ValidationEvent
+	failures    []validators.FailureEvent
+}
+
+func (o *observer) Validated(e validators.ValidationEvent) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	o.validations = append(o.validations, e)
+}
+
+func (o *observer) Failed(e validators.FailureEvent) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	o.failures = append(o.failures, e)
+}
+
+func TestObserver(t *testing.T) {
+	var o observer
+	validators.SetObserver(&o)
+	defer validators.SetObserver(nil)
+
+	tag := validators.NewStringValidator[string]().MinLen(2)
+	v := validators.NewPointerValidator[Profile](validators.MustStructValidator[Profile](validators.StructShape{
+		"Name":     validators.NewStringValidator[string]().NotEmpty(),
+		"Nickname": validators.NewStringValidator[string]().MaxLen(3).AsWarning(),
+		"Tags":     validators.NewSliceValidator[[]string](tag).AnySatisfy(tag),
+	})).NotNil()
+
+	_ = v.Check(&Profile{Nickname: "bobby", Tags: []string{"x", "go"}})
+
+	wantFailures := []validators.FailureEvent{
+		{Validator: "string", Path: "Name", Code: "notEmpty"},
+		{Validator: "string", Path: "Tags[0]", Code: "minLen"},
+	}
+	if !slices.Equal(o.failures, wantFailures) {
+		t.Errorf("expected failures %v, got %v", wantFailures, o.failures)
+	}
+
+	var got []string
+	for _, e := range o.validations {
+		got = append(got, e.Validator+" "+e.Path)
+	}
+
+	want := []string{
+		"string Name",
+		"string Nickname",
+		"string Tags[0]",
+		"string Tags[1]",
+		"[]string Tags",
+		"validators_test.Profile ",
+		"*validators_test.Profile ",
+	}
+	if !slices.Equal(got, want) {
+		t.Errorf("expected validations %q, got %q", want, got)
+	}
+
+	if last := o.validations[len(o.validations)-1]; last.Err == nil || last.Duration <= 0 {
+		t.Errorf("expected the outermost validation to fail and be timed, got %+v", last)
+	}
+}
+
+func TestSetObserverNil(t *testing.T) {
+	var o observer
+	validators.SetObserver(&o)
+	validators.SetObserver(nil)
+
+	_ = validators.NewStringValidator[string]().NotEmpty().Validate("")
+
+	if len(o.validations) != 0 || len(o.failures) != 0 {
+		t.Errorf("expected no events after resetting the observer, got %v and %v", o.validations, o.failures)
+	}
+}
@@ -0,0 +1,151 @@
+package validators
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"slices"
+	"strings"
+)
+
+// StructOption configures the checks NewStructValidator does at construction.
+type StructOption func(*structOptions)
+
+type structOptions struct {
+	exhaustive bool
+	ignore     []string
+	typ        reflect.Type
+}
+
+// Exhaustive makes NewStructValidator fail if an exported field of the struct
+// has no validator in the shape, so that fields added to the struct later
+// cannot go unvalidated by accident. A field counts as covered if the shape
+// has an entry for it or for a path through it, like "Address.City" for
+// Address. Fields of embedded structs are covered by entries for their
+// promoted names, unless the embedded field itself has an entry.
+func Exhaustive() StructOption {
+	return func(o *structOptions) {
+		o.exhaustive = true
+	}
+}
+
+// Ignore exempts the named fields from Exhaustive. Names are given like the
+// keys of StructShape and must refer to exported fields.
+func Ignore(fields ...string) StructOption {
+	return func(o *structOptions) {
+		o.ignore = append(o.ignore, fields...)
+	}
+}
+
+// ForType makes a validator of an interface type T, such as any, resolve its
+// fields against t when it is built, like a validator of t does, instead of
+// against the dynamic type of every value. Values must then be of type t.
+// Adapters that build validators for types only known at run time, like
+// validconfig, use it.
+func ForType(t reflect.Type) StructOption {
+	return func(o *structOptions) {
+		o.typ = t
+	}
+}
+
+// structType returns the type NewStructValidator resolves the fields of a
+// validator of t against, see ForType.
+func (o *structOptions) structType(t reflect.Type) (reflect.Type, error) {
+	switch {
+	case o.typ == nil:
+		return t, nil
+	case t.Kind() != reflect.Interface || !o.typ.Implements(t):
+		return nil, fmt.Errorf("validators: ForType requires an interface type implemented by %s, not %s", o.typ, t)
+	}
+
+	return o.typ, nil
+}
+
+// checkCoverage reports the exported fields of t that neither shape nor the
+// ignored names cover.
+func (o *structOptions) checkCoverage(t reflect.Type, shape StructShape) error {
+	if !o.exhaustive && len(o.ignore) == 0 {
+		return nil
+	}
+
+	if t.Kind() != reflect.Struct {
+		return fmt.Errorf("validators: Exhaustive requires a struct type, not %s", t)
+	}
+
+	var errs []error
+	covered := map[string]bool{}
+	for key := range shape {
+		covered[key] = true
+	}
+
+	for _, name := range o.ignore {
+		if _, _, err := resolveField(t, name); err != nil {
+			errs = append(errs, fmt.Errorf("validators: %s.%s: cannot ignore: %w", t, name, err))
+		}
+
+		covered[name] = true
+	}
+
+	if o.exhaustive {
+		for _, name := range uncovered(t, t, covered, nil) {
+			errs = append(errs, fmt.Errorf("validators: %s.%s: no validator for field", t, name))
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// uncovered returns the names of the exported fields of t that are not
+// covered, including those promoted from embedded structs. index is the
+// index in top of the struct t is embedded as, if any.
+func uncovered(top, t reflect.Type, covered map[string]bool, index []int) []string {
+	var names []string
+	for i := range t.NumField() {
+		f := t.Field(i)
+		if !f.IsExported() || isCovered(f.Name, covered) {
+			// Fields promoted through unexported embedded structs cannot be
+			// validated, so they need no validator either.
+			continue
+		}
+
+		fieldIndex := append(slices.Clip(index), i)
+
+		if f.Anonymous {
+			et := f.Type
+			if et.Kind() == reflect.Pointer {
+				et = et.Elem()
+			}
+
+			if et.Kind() == reflect.Struct {
+				names = append(names, uncovered(top, et, covered, fieldIndex)...)
+				continue
+			}
+		}
+
+		// Skip promoted fields that are shadowed by, or ambiguous with,
+		// other fields of the same name.
+		if sf, ok := top.FieldByName(f.Name); !ok || !slices.Equal(sf.Index, fieldIndex) {
+			continue
+		}
+
+		names = append(names, f.Name)
+	}
+
+	slices.Sort(names)
+
+	return names
+}
+
+func isCovered(name string, covered map[string]bool) bool {
+	if covered[name] {
+		return true
+	}
+
+	for key := range covered {
+		if strings.HasPrefix(key, name+".") {
+			return true
+		}
+	}
+
+	return false
+}
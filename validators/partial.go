@@ -0,0 +1,189 @@
+package validators
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// partialValidator is implemented by validators that can validate some of
+// the fields of a value while ignoring the others.
+type partialValidator interface {
+	validatePartialAny(ev *Evaluation, value any, fields []string) error
+}
+
+var (
+	_ partialValidator = &StructValidator[struct{}]{}
+	_ partialValidator = &pointerValidator[int, NumberValidator[int]]{}
+)
+
+// ValidatePartial validates only the fields of value named in fields, such as
+// the fields a PATCH request sets, so that absent fields do not fail rules
+// like NotEmpty. Names are given like the keys of StructShape. A name selects
+// the shape entries for the field itself and for paths through it, and a
+// dotted name like "Address.City" selects the entry for that path as well as
+// the matching fields of a struct validator for Address, also behind a
+// pointer validator, whose own rules such as NotNil still apply. Validators
+// that cannot validate parts of a value are run in full when any of the
+// fields they cover is selected. Rules of v itself, like NotZero, are not
+// checked.
+//
+// Use FieldsFromJSON to derive fields from a JSON request body.
+func (v *StructValidator[T]) ValidatePartial(value T, fields []string) error {
+	ev, done := v.limitIn(v.evaluation())
+	defer done()
+
+	return v.validateShape(ev, value, fields, true)
+}
+
+func (v *StructValidator[T]) validatePartialAny(ev *Evaluation, value any, fields []string) error {
+	t, ok := value.(T)
+	if !ok {
+		return newError(CodeType, "expected value of type %T, but found %T", t, value)
+	}
+
+	return v.validateShape(ev, t, fields, true)
+}
+
+func (v *pointerValidator[T, V]) validatePartialAny(ev *Evaluation, value any, fields []string) error {
+	t, ok := value.(*T)
+	if !ok {
+		return newError(CodeType, "expected value of type %T, but found %T", t, value)
+	}
+
+	for _, r := range v.rules {
+		// The unnamed rule validates the element in full.
+		if r.Name == "" || !ev.Checks(r.Rule) {
+			continue
+		}
+
+		if err := ev.report(r.Rule, r.run(ev, t), r.checkIn == nil); err != nil {
+			return err
+		}
+	}
+
+	if t == nil {
+		return nil
+	}
+
+	if p, ok := any(v.elemValidator).(partialValidator); ok {
+		return p.validatePartialAny(ev, *t, fields)
+	}
+
+	return validateIn[T](ev, v.elemValidator, *t)
+}
+
+// selectField reports whether fields select the shape entry for key in full,
+// or else the paths below key they select.
+func selectField(fields []string, key string) (all bool, rest []string) {
+	for _, f := range fields {
+		switch {
+		case f == key || strings.HasPrefix(key, f+"."):
+			return true, nil
+		case strings.HasPrefix(f, key+"."):
+			rest = append(rest, f[len(key)+1:])
+		}
+	}
+
+	return false, rest
+}
+
+// FieldsFromJSON returns the names of the fields of T that the JSON object in
+// data sets, for use with ValidatePartial. JSON keys are matched to fields
+// like encoding/json does, and nested objects for struct fields yield dotted
+// names like "Address.City" for each of their keys, or the name of the field
+// itself, e.g. "Address", if they set none of its fields.
+func FieldsFromJSON[T any](data []byte) ([]string, error) {
+	var fields []string
+	if err := jsonFields(reflect.TypeFor[T](), data, "", &fields); err != nil {
+		return nil, fmt.Errorf("validators: %w", err)
+	}
+
+	return fields, nil
+}
+
+func jsonFields(t reflect.Type, data []byte, prefix string, fields *[]string) error {
+	var obj map[string]json.RawMessage
+	if err := json.Unmarshal(data, &obj); err != nil {
+		return err
+	}
+
+	for key, raw := range obj {
+		f, ok := jsonField(t, key)
+		if !ok {
+			continue
+		}
+
+		name := prefix + f.Name
+
+		ft := f.Type
+		for ft.Kind() == reflect.Pointer {
+			ft = ft.Elem()
+		}
+
+		if ft.Kind() == reflect.Struct && bytes.HasPrefix(bytes.TrimSpace(raw), []byte("{")) {
+			n := len(*fields)
+			if err := jsonFields(ft, raw, name+".", fields); err != nil {
+				return err
+			}
+
+			// The field itself was set even if the object sets none of
+			// its fields.
+			if len(*fields) > n {
+				continue
+			}
+		}
+
+		*fields = append(*fields, name)
+	}
+
+	return nil
+}
+
+// jsonField returns the field of t encoding/json decodes key into.
+func jsonField(t reflect.Type, key string) (reflect.StructField, bool) {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+
+	if t.Kind() != reflect.Struct {
+		return reflect.StructField{}, false
+	}
+
+	var fold reflect.StructField
+	found := false
+	for _, f := range reflect.VisibleFields(t) {
+		tag, _, _ := strings.Cut(f.Tag.Get("json"), ",")
+		if !f.IsExported() || tag == "-" {
+			continue
+		}
+
+		// Untagged embedded structs are flattened into their fields.
+		if et := f.Type; f.Anonymous && tag == "" {
+			if et.Kind() == reflect.Pointer {
+				et = et.Elem()
+			}
+
+			if et.Kind() == reflect.Struct {
+				continue
+			}
+		}
+
+		name := f.Name
+		if tag != "" {
+			name = tag
+		}
+
+		if name == key {
+			return f, true
+		}
+
+		if !found && strings.EqualFold(name, key) {
+			fold, found = f, true
+		}
+	}
+
+	return fold, found
+}
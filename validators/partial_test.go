@@ -0,0 +1,142 @@
+package validators_test
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/bitcrshr/valid/validators"
+	"github.com/bitcrshr/valid/validtest"
+)
+
+type PatchAddress struct {
+	City string `json:"city"`
+	Zip  string `json:"zip"`
+}
+
+type PatchMeta struct {
+	Source string `json:"source"`
+}
+
+type PatchUser struct {
+	*PatchMeta
+	Name     string        `json:"name"`
+	Email    string        `json:"email,omitempty"`
+	Address  *PatchAddress `json:"address"`
+	Internal string        `json:"-"`
+	Nickname string
+}
+
+func patchUserValidator() *validators.StructValidator[PatchUser] {
+	notEmpty := validators.NewStringValidator[string]().NotEmpty()
+
+	return validators.MustStructValidator[PatchUser](validators.StructShape{
+		"Name":   notEmpty,
+		"Email":  notEmpty,
+		"Source": notEmpty,
+		"Address": validators.NewPointerValidator(validators.MustStructValidator[PatchAddress](validators.StructShape{
+			"City": notEmpty,
+			"Zip":  notEmpty.Len(5),
+		})).NotNil(),
+		"Address.City": validators.NewStringValidator[string]().In("Paris", "Rome"),
+	}).NotZero()
+}
+
+func TestValidatePartial(t *testing.T) {
+	v := patchUserValidator()
+
+	tests := []struct {
+		name   string
+		value  PatchUser
+		fields []string
+		want   []validtest.Violation
+	}{
+		{name: "no fields", value: PatchUser{}},
+		{name: "set field", value: PatchUser{Name: "Ada"}, fields: []string{"Name"}},
+		{
+			name:   "cleared field",
+			value:  PatchUser{},
+			fields: []string{"Name"},
+			want:   []validtest.Violation{{Path: "Name", Code: "notEmpty"}},
+		},
+		{
+			name:   "nested field",
+			value:  PatchUser{Address: &PatchAddress{City: "Paris"}},
+			fields: []string{"Address.City"},
+		},
+		{
+			name:   "invalid nested field",
+			value:  PatchUser{Address: &PatchAddress{City: "Oslo"}},
+			fields: []string{"Address.City"},
+			want:   []validtest.Violation{{Path: "Address.City", Code: "in"}},
+		},
+		{
+			name:   "nested field of nil pointer",
+			value:  PatchUser{},
+			fields: []string{"Address.Zip"},
+			want:   []validtest.Violation{{Path: "Address", Code: "notNil"}},
+		},
+		{
+			name:   "whole nested struct",
+			value:  PatchUser{Address: &PatchAddress{City: "Paris"}},
+			fields: []string{"Address"},
+			want:   []validtest.Violation{{Path: "Address.Zip", Code: "notEmpty"}},
+		},
+		{
+			name:   "promoted field",
+			value:  PatchUser{PatchMeta: &PatchMeta{}},
+			fields: []string{"Source"},
+			want:   []validtest.Violation{{Path: "Source", Code: "notEmpty"}},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got := validtest.Violations(v.ValidatePartial(test.value, test.fields))
+			if !slices.Equal(got, test.want) {
+				t.Errorf("expected violations %v, got %v", test.want, got)
+			}
+		})
+	}
+}
+
+func TestFieldsFromJSON(t *testing.T) {
+	fields, err := validators.FieldsFromJSON[PatchUser]([]byte(`{
+		"name": "Ada",
+		"EMAIL": "ada@example.com",
+		"address": {"city": "Paris"},
+		"source": "api",
+		"Internal": "ignored",
+		"nickname": null,
+		"unknown": 1
+	}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	slices.Sort(fields)
+
+	want := []string{"Address.City", "Email", "Name", "Nickname", "Source"}
+	if !slices.Equal(fields, want) {
+		t.Errorf("expected fields %v, got %v", want, fields)
+	}
+
+	if err := patchUserValidator().ValidatePartial(PatchUser{Name: "Ada", Email: "ada@example.com", Address: &PatchAddress{City: "Paris"}, PatchMeta: &PatchMeta{Source: "api"}}, fields); err != nil {
+		t.Errorf("expected partial validation to pass, got %v", err)
+	}
+
+	// An empty object still sets the field, so the rules of the field itself
+	// are checked.
+	fields, err = validators.FieldsFromJSON[PatchUser]([]byte(`{"address": {}}`))
+	if err != nil || !slices.Equal(fields, []string{"Address"}) {
+		t.Errorf("expected fields [Address], got %v, %v", fields, err)
+	}
+
+	got := validtest.Violations(patchUserValidator().ValidatePartial(PatchUser{}, fields))
+	if want := []validtest.Violation{{Path: "Address", Code: "notNil"}}; !slices.Equal(got, want) {
+		t.Errorf("expected violations %v, got %v", want, got)
+	}
+
+	if _, err := validators.FieldsFromJSON[PatchUser]([]byte(`[]`)); err == nil {
+		t.Errorf("expected non-object body to fail")
+	}
+}
@@ -1,7 +1,5 @@
 package validators
 
-import "fmt"
-
 type pointerValidator[T any, V Validator[T]] struct {
 	*baseValidator[*T, PointerValidator[T, V]]
 	elemValidator V
@@ -13,41 +11,66 @@ func NewPointerValidator[T any, V Validator[T]](elemValidator V) PointerValidato
 	v := &pointerValidator[T, V]{
 		elemValidator: elemValidator,
 	}
-	v.baseValidator = newBaseValidator[*T, PointerValidator[T, V]](v)
+	v.baseValidator = newBaseValidator[*T, PointerValidator[T, V]](v, v.clone)
+
+	v.check(
+		func(ev *Evaluation, t *T) error {
+			if t == nil {
+				return nil
+			}
+
+			return validateIn[T](ev, elemValidator, *t)
+		},
+	)
 
 	return v
 }
 
+func (v *pointerValidator[T, V]) clone(base *baseValidator[*T, PointerValidator[T, V]]) PointerValidator[T, V] {
+	c := *v
+	c.baseValidator = base
+
+	return &c
+}
+
 func (v *pointerValidator[T, V]) Nil() PointerValidator[T, V] {
-	v.checks = append(
-		v.checks,
+	return v.with(
+		Rule{Name: "nil"},
 		func(t *T) error {
 			if t != nil {
-				return fmt.Errorf("expected %#v to be nil", t)
+				return newError("nil", "expected %#v to be nil", input(t))
 			}
 
 			return nil
 		},
 	)
-
-	return v
 }
 
 func (v *pointerValidator[T, V]) NotNil() PointerValidator[T, V] {
-	v.checks = append(
-		v.checks,
+	return v.with(
+		Rule{Name: "notNil"},
 		func(t *T) error {
 			if t == nil {
-				return fmt.Errorf("expected %#v to not be nil", t)
+				return newError("notNil", "expected %#v to not be nil", input(t))
 			}
 
 			return nil
 		},
 	)
-
-	return v
 }
 
 func (v *pointerValidator[T, V]) ElemValidator() V {
 	return v.elemValidator
 }
+
+func (v *pointerValidator[T, V]) nested() []AnyValidator {
+	return []AnyValidator{v.elemValidator}
+}
+
+func (v *pointerValidator[T, V]) Describe() Description {
+	d := v.describe("pointer")
+	elem := Describe(v.elemValidator)
+	d.Elem = &elem
+
+	return d
+}
@@ -0,0 +1,146 @@
+package validators
+
+import (
+	"fmt"
+	"reflect"
+	"sync/atomic"
+	"unicode/utf8"
+)
+
+// Sensitive marks the values v validates as sensitive, such as passwords or
+// tokens. The errors of its rules show them only as the redactor set with
+// SetRedactor does, both in their message and in Value. Params shown in
+// messages are redacted as well, as some show the value when the rule fails,
+// such as that of NotEqualTo, and the errors carry no Params. Values of nested
+// validators, such as slice elements, are only redacted if those are marked
+// as well, but the rules of a validator nesting a sensitive one, such as a
+// struct with a sensitive field, redact the whole value they show. Messages
+// of errors returned by checks passed to Satisfies are left as they are.
+func (v *baseValidator[T, Super]) Sensitive() Super {
+	v.mustNotBeSealed("sensitive")
+
+	return v.deriveWith(func(next *baseValidator[T, Super]) {
+		next.sensitive = true
+	})
+}
+
+// showsSensitive reports whether the errors of v show sensitive values,
+// because v or a validator nested in it is sensitive.
+func (v *baseValidator[T, Super]) showsSensitive() bool {
+	return v.sensitive || v.sensitiveNested
+}
+
+// showsSensitive reports whether the errors of the validator v may show
+// sensitive values. Validators outside of this package are asked for their
+// description.
+func showsSensitive(v any) bool {
+	if s, ok := v.(interface{ showsSensitive() bool }); ok {
+		return s.showsSensitive()
+	}
+
+	if d, ok := v.(Describer); ok {
+		return describesSensitive(d.Describe())
+	}
+
+	return false
+}
+
+func describesSensitive(d Description) bool {
+	if d.Sensitive || (d.Elem != nil && describesSensitive(*d.Elem)) {
+		return true
+	}
+
+	for _, f := range d.Fields {
+		if describesSensitive(f) {
+			return true
+		}
+	}
+
+	for _, r := range d.Rules {
+		for _, p := range r.Params {
+			if pd, ok := p.(Description); ok && describesSensitive(pd) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// Redactor formats sensitive values for errors.
+type Redactor func(value any) string
+
+var redactor atomic.Pointer[Redactor]
+
+// SetRedactor sets how sensitive values are shown in errors, see Sensitive.
+// Passing nil restores the default, RedactAll.
+func SetRedactor(r Redactor) {
+	if r == nil {
+		redactor.Store(nil)
+		return
+	}
+
+	redactor.Store(&r)
+}
+
+// RedactAll shows every value as "[redacted]".
+func RedactAll(any) string {
+	return "[redacted]"
+}
+
+// RedactPartial shows only the first character and the length of strings,
+// e.g. "p… (8 chars)", and only the length of slices and maps. Other values
+// are shown like by RedactAll.
+func RedactPartial(value any) string {
+	rv := reflect.ValueOf(value)
+	switch {
+	case rv.Kind() == reflect.String && rv.Len() > 0:
+		s := rv.String()
+		first, _ := utf8.DecodeRuneInString(s)
+
+		return fmt.Sprintf("%c… (%d chars)", first, utf8.RuneCountInString(s))
+	case rv.Kind() == reflect.Slice, rv.Kind() == reflect.Map, rv.Kind() == reflect.String:
+		return fmt.Sprintf("[redacted, len %d]", rv.Len())
+	}
+
+	return RedactAll(value)
+}
+
+func redact(value any) string {
+	if r := redactor.Load(); r != nil {
+		return (*r)(value)
+	}
+
+	return RedactAll(value)
+}
+
+// redacted formats as the redacted value it holds.
+type redacted string
+
+func (r redacted) String() string {
+	return string(r)
+}
+
+// redactError returns a copy of e whose message and value do not show value,
+// with the input arguments and params of its message redacted.
+func redactError(e *Error, value any) *Error {
+	c := *e
+	c.Value = redacted(redact(value))
+
+	if c.format != "" {
+		c.args = make([]any, len(e.args))
+		for i, arg := range e.args {
+			c.args[i] = arg
+			switch a := arg.(type) {
+			case inputArg:
+				c.args[i] = redacted(redact(a.value))
+			case boundedArg:
+				c.args[i] = redacted(redact(a.value))
+			}
+		}
+
+		c.Message = fmt.Sprintf(c.format, c.args...)
+	}
+
+	return &c
+}
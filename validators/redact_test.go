@@ -0,0 +1,143 @@
+package validators_test
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"log/slog"
+	"strings"
+	"testing"
+
+	"github.com/bitcrshr/valid/validators"
+)
+
+type Login struct {
+	User     string
+	Password string
+}
+
+func TestSensitive(t *testing.T) {
+	const secret = "hunter2"
+
+	base := validators.NewStringValidator[string]().MinLen(10)
+	sensitive := base.Sensitive().NotContains("2")
+
+	tests := []struct {
+		name  string
+		err   error
+		leaks bool
+	}{
+		{name: "not sensitive", err: base.Validate(secret), leaks: true},
+		{name: "rule before Sensitive", err: sensitive.Validate(secret)},
+		{name: "rule after Sensitive", err: sensitive.Validate(secret + "1234567890")},
+		{
+			name: "struct field",
+			err: validators.MustStructValidator[Login](validators.StructShape{
+				"Password": sensitive,
+			}).Validate(Login{Password: secret}),
+		},
+		{
+			name: "struct with a sensitive field",
+			err: validators.MustStructValidator[Login](validators.StructShape{
+				"Password": validators.NewStringValidator[string]().Sensitive(),
+			}).Zero().Validate(Login{User: "root", Password: secret}),
+		},
+		{
+			name: "shadowed struct field",
+			err: validators.MustStructValidator[Login](validators.StructShape{
+				"Password": validators.NewShadowValidator[string](
+					validators.NewStringValidator[string]().Sensitive(),
+					validators.NewStringValidator[string](),
+					validators.ShadowSinkFunc(func(validators.Disagreement) {}),
+				),
+			}).Zero().Validate(Login{Password: secret}),
+		},
+		{
+			name: "noneSatisfy element",
+			err: validators.NewSliceValidator[[]string](validators.NewStringValidator[string]()).
+				Sensitive().
+				NoneSatisfy(validators.NewStringValidator[string]().HasPrefix("hunter")).
+				Validate([]string{"ok", secret}),
+		},
+		{
+			name: "notEqualTo",
+			err:  validators.NewStringValidator[string]().Sensitive().NotEqualTo(secret).Validate(secret),
+		},
+		{
+			name: "notIn",
+			err:  validators.NewStringValidator[string]().Sensitive().NotIn("admin", secret).Validate(secret),
+		},
+		{
+			name: "number",
+			err:  validators.NewNumberValidator[int]().Sensitive().LT(1000).Validate(1234),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if test.err == nil {
+				t.Fatal("expected an error")
+			}
+
+			e := validators.AsErrors(test.err)[0]
+			shown := test.err.Error() + fmt.Sprint(e.Value)
+			if leaks := strings.Contains(shown, secret) || strings.Contains(shown, "1234"); leaks != test.leaks {
+				t.Errorf("expected leaking the value to be %t, got %q", test.leaks, shown)
+			}
+
+			if !test.leaks && !strings.Contains(test.err.Error(), "[redacted]") {
+				t.Errorf("expected the value to be redacted, got %q", test.err)
+			}
+		})
+	}
+
+	if base.Describe().Sensitive || !sensitive.Describe().Sensitive {
+		t.Errorf("expected Sensitive to mark only the derived validator")
+	}
+}
+
+func TestSensitiveLogValue(t *testing.T) {
+	const secret = "hunter2"
+
+	err := validators.NewStringValidator[string]().Sensitive().NotIn("admin", secret).Validate(secret)
+	if e := validators.AsErrors(err)[0]; e.Params != nil {
+		t.Errorf("expected no params, got %v", e.Params)
+	}
+
+	var b bytes.Buffer
+	slog.New(slog.NewJSONHandler(&b, nil)).LogAttrs(context.Background(), slog.LevelInfo, "invalid", validators.LogAttrs(err)...)
+	if strings.Contains(b.String(), secret) || strings.Contains(b.String(), "params") {
+		t.Errorf("expected the params not to be logged, got %s", b.String())
+	}
+}
+
+func TestSensitiveShadowValidator(t *testing.T) {
+	var got []validators.Disagreement
+	v := validators.NewShadowValidator[string](
+		validators.NewStringValidator[string]().Sensitive(),
+		validators.NewStringValidator[string]().MinLen(10),
+		validators.ShadowSinkFunc(func(d validators.Disagreement) { got = append(got, d) }),
+	)
+
+	if err := v.Validate("hunter2"); err != nil {
+		t.Fatalf("expected the active validator to pass, got %v", err)
+	}
+
+	if len(got) != 1 || fmt.Sprint(got[0].Value) != "[redacted]" || strings.Contains(got[0].CandidateOnly.Error(), "hunter2") {
+		t.Errorf("expected a disagreement with the value redacted, got %+v", got)
+	}
+}
+
+func TestSetRedactor(t *testing.T) {
+	validators.SetRedactor(validators.RedactPartial)
+	defer validators.SetRedactor(nil)
+
+	err := validators.NewStringValidator[string]().Sensitive().MinLen(10).Validate("hunter2")
+	if want := "expected `h… (7 chars)` to have min len 10, but got 7"; err == nil || err.Error() != want {
+		t.Errorf("expected %q, got %v", want, err)
+	}
+
+	if got := validators.RedactPartial([]int{1, 2, 3}); got != "[redacted, len 3]" {
+		t.Errorf("expected slices to show their length, got %q", got)
+	}
+}
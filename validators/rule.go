@@ -0,0 +1,47 @@
+package validators
+
+// Rule describes a single check performed by a validator, e.g.
+// Rule{Name: "minLen", Params: []any{5}}. The name of a rule is also the code
+// of the errors it reports.
+type Rule struct {
+	Name   string `json:"name"`
+	Params []any  `json:"params,omitempty"`
+	// Groups are the validation groups the rule belongs to, see Groups. Rules
+	// in no group are always checked.
+	Groups []string `json:"groups,omitempty"`
+	// Warning is set for rules whose violations are reported as warnings,
+	// see AsWarning.
+	Warning bool `json:"warning,omitempty"`
+}
+
+// rule pairs a Rule with the check that implements it. Checks that validators
+// add on their own behalf, such as validating slice elements, have no name
+// and are not reported by Rules.
+type rule[T any] struct {
+	Rule
+	check func(T) error
+	// checkIn replaces check for rules that validate nested values, which
+	// need the context of the validation.
+	checkIn func(*Evaluation, T) error
+	// span is set for rules that only bound the length of values, see fuse.
+	span *span[T]
+	// parts are the rules a fused rule was fused from.
+	parts []rule[T]
+}
+
+func (r rule[T]) run(ev *Evaluation, value T) error {
+	if r.checkIn != nil {
+		return r.checkIn(ev, value)
+	}
+
+	return r.check(value)
+}
+
+func params[E any](es []E) []any {
+	ps := make([]any, len(es))
+	for i, e := range es {
+		ps[i] = e
+	}
+
+	return ps
+}
@@ -0,0 +1,77 @@
+package validators
+
+import "reflect"
+
+// scalar holds the value of a string or number field, read with the
+// accessors of reflect.Value. Unlike reflect.Value.Interface, these do not
+// box the field, nor make the struct it is read from escape to the heap.
+type scalar struct {
+	kind reflect.Kind
+	s    string
+	i    int64
+	u    uint64
+	f    float64
+}
+
+// scalarOf returns the value of rv, which must be a string or number.
+func scalarOf(rv reflect.Value) scalar {
+	switch s := (scalar{kind: rv.Kind()}); {
+	case s.kind == reflect.String:
+		s.s = rv.String()
+		return s
+	case rv.CanInt():
+		s.i = rv.Int()
+		return s
+	case rv.CanUint():
+		s.u = rv.Uint()
+		return s
+	default:
+		s.f = rv.Float()
+		return s
+	}
+}
+
+// scalarValidator is implemented by the validators of strings and numbers,
+// which struct validators give their fields as scalars, see validateFlat.
+type scalarValidator interface {
+	validateScalarIn(ev *Evaluation, s scalar) error
+}
+
+var (
+	_ scalarValidator = &stringValidator[string]{}
+	_ scalarValidator = &numberValidator[int]{}
+)
+
+func (v *stringValidator[T]) validateScalarIn(ev *Evaluation, s scalar) error {
+	return v.validateIn(ev, T(s.s))
+}
+
+func (v *numberValidator[T]) validateScalarIn(ev *Evaluation, s scalar) error {
+	switch {
+	case s.kind >= reflect.Int && s.kind <= reflect.Int64:
+		return v.validateIn(ev, T(s.i))
+	case s.kind >= reflect.Uint && s.kind <= reflect.Uintptr:
+		return v.validateIn(ev, T(s.u))
+	default:
+		return v.validateIn(ev, T(s.f))
+	}
+}
+
+// scalarFor returns fv as a scalarValidator if it validates values of type
+// t, a field type, and t is a string or number type.
+func scalarFor(fv AnyValidator, t reflect.Type) scalarValidator {
+	sv, ok := fv.(scalarValidator)
+	if !ok || valueType(fv) != t {
+		return nil
+	}
+
+	switch t.Kind() {
+	case reflect.String,
+		reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr,
+		reflect.Float32, reflect.Float64:
+		return sv
+	}
+
+	return nil
+}
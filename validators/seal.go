@@ -0,0 +1,47 @@
+package validators
+
+import "fmt"
+
+// Seal makes v and every validator nested in it, such as element validators,
+// struct fields and validators passed to rules, immutable. Builder methods on
+// sealed validators panic. Sealed validators are safe for concurrent use, and
+// sealing one that is already in use is safe as well.
+func (v *baseValidator[T, Super]) Seal() Super {
+	v.seal()
+	return v.super
+}
+
+// Sealed reports whether Seal was called on v or a validator it is nested in.
+func (v *baseValidator[T, Super]) Sealed() bool {
+	return v.sealed.Load()
+}
+
+func (v *baseValidator[T, Super]) seal() {
+	if v.sealed.Swap(true) {
+		return
+	}
+
+	for _, r := range v.rules {
+		for _, p := range r.Params {
+			sealNested(p)
+		}
+	}
+
+	if p, ok := any(v.super).(interface{ nested() []AnyValidator }); ok {
+		for _, n := range p.nested() {
+			sealNested(n)
+		}
+	}
+}
+
+func (v *baseValidator[T, Super]) mustNotBeSealed(rule string) {
+	if v.sealed.Load() {
+		panic(fmt.Sprintf("validators: cannot add rule %s to sealed validator", rule))
+	}
+}
+
+func sealNested(v any) {
+	if s, ok := v.(interface{ seal() }); ok {
+		s.seal()
+	}
+}
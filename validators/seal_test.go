@@ -0,0 +1,85 @@
+package validators_test
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/bitcrshr/valid/validators"
+)
+
+func TestSeal(t *testing.T) {
+	type Foo struct {
+		Bar  string
+		Tags []string
+	}
+
+	name := validators.NewStringValidator[string]().NotEmpty()
+	tag := validators.NewStringValidator[string]().MinLen(2)
+	tags := validators.NewSliceValidator[[]string](tag).
+		AllSatisfy(validators.NewStringValidator[string]().MaxLen(10))
+	v := validators.NewPointerValidator(validators.MustStructValidator[Foo](validators.StructShape{
+		"Bar":  name,
+		"Tags": tags,
+	})).Seal()
+
+	for _, s := range []interface{ Sealed() bool }{v, v.ElemValidator(), name, tags, tag, tags.Rules()[0].Params[0].(validators.StringValidator[string])} {
+		if !s.Sealed() {
+			t.Errorf("expected %T to be sealed", s)
+		}
+	}
+
+	expectPanic := func(name string, f func()) {
+		t.Helper()
+
+		defer func() {
+			if recover() == nil {
+				t.Errorf("expected %s on sealed validator to panic", name)
+			}
+		}()
+
+		f()
+	}
+
+	expectPanic("NotNil", func() { v.NotNil() })
+	expectPanic("Extend", func() { v.ElemValidator().Extend(nil) })
+	expectPanic("MaxLen", func() { name.MaxLen(5) })
+
+	if validators.NewStringValidator[string]().Sealed() {
+		t.Errorf("expected new validator not to be sealed")
+	}
+}
+
+// TestSealConcurrent is meant to be run with -race.
+func TestSealConcurrent(t *testing.T) {
+	type Foo struct {
+		Bar string
+	}
+
+	v := validators.MustStructValidator[Foo](validators.StructShape{
+		"Bar": validators.NewStringValidator[string]().MinLen(3),
+	}).NotZero()
+
+	var wg sync.WaitGroup
+	for i := range 8 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			if i%2 == 0 {
+				v.Seal()
+			}
+
+			for range 100 {
+				_ = v.Validate(Foo{Bar: "baz"})
+				_ = v.Shape()
+				_ = v.Rules()
+			}
+		}()
+	}
+
+	wg.Wait()
+
+	if !v.Sealed() {
+		t.Errorf("expected validator to be sealed")
+	}
+}
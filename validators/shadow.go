@@ -0,0 +1,230 @@
+package validators
+
+import (
+	"math/rand/v2"
+	"slices"
+)
+
+// ShadowValidator validates values with an active validator and, for a
+// sample of them, also with a candidate, such as a stricter version of the
+// active validator that is being rolled out. Only the active validator
+// decides the result; violations on which the two disagree are reported to a
+// ShadowSink.
+type ShadowValidator[T any] struct {
+	active    Validator[T]
+	candidate Validator[T]
+	sink      ShadowSink
+	rate      float64
+	sensitive bool
+}
+
+var _ EvaluationValidator = &ShadowValidator[int]{}
+
+// Disagreement describes a value the active and candidate validators of a
+// ShadowValidator judged differently. Paths of the violations are those of
+// the validated values, e.g. "Tags[0]" for a ShadowValidator of the field
+// Tags.
+type Disagreement struct {
+	// Value is the validated value. It, and the values the violations show,
+	// are redacted if either validator is or nests a Sensitive validator.
+	Value any
+	// CandidateOnly are the violations only the candidate reports, which
+	// would start to fail if it became active.
+	CandidateOnly Errors
+	// ActiveOnly are the violations only the active validator reports, which
+	// would pass if the candidate became active.
+	ActiveOnly Errors
+}
+
+// ShadowSink receives the disagreements found by a ShadowValidator. Report is
+// called synchronously by Validate, possibly from several goroutines at
+// once.
+type ShadowSink interface {
+	Report(d Disagreement)
+}
+
+// ShadowSinkFunc adapts a function to a ShadowSink.
+type ShadowSinkFunc func(d Disagreement)
+
+func (f ShadowSinkFunc) Report(d Disagreement) {
+	f(d)
+}
+
+// ShadowOption configures a ShadowValidator.
+type ShadowOption func(*shadowOptions)
+
+type shadowOptions struct {
+	rate float64
+}
+
+// SampleRate sets the fraction of values, between 0 and 1, that are also
+// validated by the candidate. It defaults to 1, i.e. every value.
+func SampleRate(rate float64) ShadowOption {
+	return func(o *shadowOptions) {
+		o.rate = min(max(rate, 0), 1)
+	}
+}
+
+// NewShadowValidator returns a validator that returns the result of active and
+// reports to sink where candidate disagrees with it. A violation is a
+// disagreement unless both validators report it with the same path and code.
+// Panics of the candidate are recovered and otherwise ignored, so that it
+// never affects the result.
+func NewShadowValidator[T any](active, candidate Validator[T], sink ShadowSink, opts ...ShadowOption) *ShadowValidator[T] {
+	o := shadowOptions{rate: 1}
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	return &ShadowValidator[T]{
+		active:    active,
+		candidate: candidate,
+		sink:      sink,
+		rate:      o.rate,
+		sensitive: showsSensitive(active) || showsSensitive(candidate),
+	}
+}
+
+func (v *ShadowValidator[T]) Validate(value T) error {
+	return v.validateIn(tracking(NewEvaluation()), value)
+}
+
+func (v *ShadowValidator[T]) ValidateAny(value any) error {
+	return v.ValidateAnyIn(tracking(NewEvaluation()), value)
+}
+
+func (v *ShadowValidator[T]) ValidateAnyIn(ev *Evaluation, value any) error {
+	t, ok := value.(T)
+	if !ok {
+		return newError(CodeType, "expected value of type %T, but found %T", t, value)
+	}
+
+	return v.validateIn(ev, t)
+}
+
+func (v *ShadowValidator[T]) validateIn(ev *Evaluation, value T) error {
+	err := validateIn(ev, v.active, value)
+
+	if v.rate >= 1 || (v.rate > 0 && rand.Float64() < v.rate) {
+		v.shadow(ev, value, err)
+	}
+
+	return err
+}
+
+// shadow validates value with the candidate, in the groups of ev, and reports
+// how the result differs from err, that of the active validator.
+func (v *ShadowValidator[T]) shadow(ev *Evaluation, value T, err error) {
+	// The candidate is not observed, as its violations are not real.
+	var candidateErr error
+	func() {
+		defer func() { _ = recover() }()
+		candidateErr = validateIn(ev.probe(), v.candidate, value)
+	}()
+
+	active, candidate := AsErrors(err), AsErrors(candidateErr)
+	d := Disagreement{
+		Value:         v.value(value),
+		CandidateOnly: ev.absolute(v.redact(missingFrom(active, candidate))),
+		ActiveOnly:    ev.absolute(v.redact(missingFrom(candidate, active))),
+	}
+
+	if len(d.CandidateOnly) > 0 || len(d.ActiveOnly) > 0 {
+		v.sink.Report(d)
+	}
+}
+
+// missingFrom returns the violations of errs for which base has none with
+// the same path and code.
+func missingFrom(base, errs Errors) Errors {
+	var missing Errors
+	for _, e := range errs {
+		if !slices.ContainsFunc(base, func(b *Error) bool { return b.Path == e.Path && b.Code == e.Code }) {
+			missing = append(missing, e)
+		}
+	}
+
+	return missing
+}
+
+// absolute returns copies of errs with paths from the root value of ev.
+func (ev *Evaluation) absolute(errs Errors) Errors {
+	if ev == nil || len(ev.path) == 0 || len(errs) == 0 {
+		return errs
+	}
+
+	abs := make(Errors, len(errs))
+	for i, e := range errs {
+		c := *e
+		c.Path = ev.pathTo(e.Path)
+		abs[i] = &c
+	}
+
+	return abs
+}
+
+// tracksPaths reports that v needs the path to the values it validates, to
+// report disagreements with it.
+func (v *ShadowValidator[T]) tracksPaths() bool {
+	return true
+}
+
+// showsSensitive reports whether either validator of v is or nests a
+// sensitive validator.
+func (v *ShadowValidator[T]) showsSensitive() bool {
+	return v.sensitive
+}
+
+// value returns value as reported in disagreements, redacted if either
+// validator of v is sensitive.
+func (v *ShadowValidator[T]) value(value T) any {
+	if v.sensitive {
+		return redacted(redact(value))
+	}
+
+	return value
+}
+
+// redact returns errs with the values they show redacted if either validator
+// of v is sensitive, as those of the other one are not redacted otherwise.
+func (v *ShadowValidator[T]) redact(errs Errors) Errors {
+	if !v.sensitive || len(errs) == 0 {
+		return errs
+	}
+
+	c := make(Errors, len(errs))
+	for i, e := range errs {
+		c[i] = e
+		if _, ok := e.Value.(redacted); !ok {
+			c[i] = redactError(e, e.Value)
+		}
+	}
+
+	return c
+}
+
+// Active returns the validator that decides the result.
+func (v *ShadowValidator[T]) Active() Validator[T] {
+	return v.active
+}
+
+// Candidate returns the validator run in the shadow of the active one.
+func (v *ShadowValidator[T]) Candidate() Validator[T] {
+	return v.candidate
+}
+
+// seal seals both validators, see Seal.
+func (v *ShadowValidator[T]) seal() {
+	sealNested(v.active)
+	sealNested(v.candidate)
+}
+
+// Describe describes the active validator.
+func (v *ShadowValidator[T]) Describe() Description {
+	return Describe(v.active)
+}
+
+// String renders the active validator, see Description.String.
+func (v *ShadowValidator[T]) String() string {
+	return v.Describe().String()
+}
@@ -0,0 +1,121 @@
+package validators_test
+
+import (
+	"slices"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/bitcrshr/valid/validators"
+	"github.com/bitcrshr/valid/validtest"
+)
+
+// recorder is a ShadowSink that keeps the disagreements it receives.
+type recorder struct {
+	mu sync.Mutex
+	ds []validators.Disagreement
+}
+
+func (r *recorder) Report(d validators.Disagreement) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.ds = append(r.ds, d)
+}
+
+func TestShadowValidator(t *testing.T) {
+	var sink recorder
+	v := validators.NewShadowValidator[string](
+		validators.NewStringValidator[string]().MaxLen(255),
+		validators.NewStringValidator[string]().NotEmpty().MaxLen(100),
+		&sink,
+	)
+
+	validtest.AssertValid(t, v, "short")
+	validtest.AssertValid(t, v, strings.Repeat("a", 200))
+	validtest.AssertInvalid(t, v, strings.Repeat("a", 300), "", "maxLen")
+	validtest.AssertValid(t, v, "")
+
+	if len(sink.ds) != 2 {
+		t.Fatalf("expected 2 disagreements, got %+v", sink.ds)
+	}
+
+	if got := validtest.Violations(sink.ds[0].CandidateOnly); !slices.Equal(got, []validtest.Violation{{Code: "maxLen"}}) {
+		t.Errorf("expected the candidate to fail maxLen, got %v", got)
+	}
+
+	if got := validtest.Violations(sink.ds[1].CandidateOnly); !slices.Equal(got, []validtest.Violation{{Code: "notEmpty"}}) {
+		t.Errorf("expected the candidate to fail notEmpty, got %v", got)
+	}
+
+	if sink.ds[1].Value != "" || sink.ds[1].ActiveOnly != nil {
+		t.Errorf("expected the empty value and no violations of the active validator, got %+v", sink.ds[1])
+	}
+}
+
+func TestShadowValidatorNested(t *testing.T) {
+	var sink recorder
+	v := validators.MustStructValidator[Profile](validators.StructShape{
+		"Tags": validators.NewShadowValidator[[]string](
+			validators.NewSliceValidator[[]string](validators.NewStringValidator[string]().MinLen(3)),
+			validators.NewSliceValidator[[]string](validators.NewStringValidator[string]().MinLen(1)),
+			&sink,
+		),
+	})
+
+	validtest.AssertInvalid(t, v, Profile{Tags: []string{"go", "rust"}}, "Tags[0]", "minLen")
+
+	if len(sink.ds) != 1 {
+		t.Fatalf("expected 1 disagreement, got %+v", sink.ds)
+	}
+
+	if got := validtest.Violations(sink.ds[0].ActiveOnly); !slices.Equal(got, []validtest.Violation{{Path: "Tags[0]", Code: "minLen"}}) {
+		t.Errorf("expected the active validator to fail Tags[0], got %v", got)
+	}
+
+	// The path is tracked through pointers and in groups as well.
+	sink.ds = nil
+	p := validators.NewPointerValidator(v)
+	validtest.AssertInvalid(t, p, &Profile{Tags: []string{"go"}}, "Tags[0]", "minLen")
+	_ = p.ValidateGroups(&Profile{Tags: []string{"go"}}, "strict")
+
+	for _, d := range sink.ds {
+		if got := validtest.Violations(d.ActiveOnly); !slices.Equal(got, []validtest.Violation{{Path: "Tags[0]", Code: "minLen"}}) {
+			t.Errorf("expected the active validator to fail Tags[0], got %v", got)
+		}
+	}
+
+	if len(sink.ds) != 2 {
+		t.Errorf("expected 2 disagreements, got %+v", sink.ds)
+	}
+}
+
+func TestShadowValidatorSampleRate(t *testing.T) {
+	var sink recorder
+	v := validators.NewShadowValidator[string](
+		validators.NewStringValidator[string](),
+		validators.NewStringValidator[string]().NotEmpty(),
+		&sink,
+		validators.SampleRate(0),
+	)
+
+	for range 100 {
+		validtest.AssertValid(t, v, "")
+	}
+
+	if len(sink.ds) != 0 {
+		t.Errorf("expected no disagreements at sample rate 0, got %d", len(sink.ds))
+	}
+}
+
+func TestShadowValidatorCandidatePanics(t *testing.T) {
+	var sink recorder
+	v := validators.NewShadowValidator[string](
+		validators.NewStringValidator[string]().NotEmpty(),
+		validators.NewStringValidator[string]().Satisfies(func(string) error { panic("boom") }),
+		&sink,
+	)
+
+	validtest.AssertValid(t, v, "ok")
+	validtest.AssertInvalid(t, v, "", "", "notEmpty")
+}
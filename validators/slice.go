@@ -1,6 +1,9 @@
 package validators
 
-import "fmt"
+import (
+	"math"
+	"strconv"
+)
 
 type sliceValidator[S ~[]E, E any, V Validator[E]] struct {
 	*baseValidator[S, SliceValidator[S, E, V]]
@@ -13,149 +16,174 @@ func NewSliceValidator[S ~[]E, E any, V Validator[E]](elemValidator V) SliceVali
 	v := &sliceValidator[S, E, V]{
 		elemValidator: elemValidator,
 	}
-	v.baseValidator = newBaseValidator[S, SliceValidator[S, E, V]](v)
+	v.baseValidator = newBaseValidator[S, SliceValidator[S, E, V]](v, v.clone)
 
-	v.checks = append(
-		v.checks,
-		func(s S) error {
-			for i, el := range s {
-				if err := elemValidator.Validate(el); err != nil {
-					return fmt.Errorf("element at index %d did not satisfy elem validator: %v", i, err)
-				}
-			}
-
-			return nil
+	v.check(
+		func(ev *Evaluation, s S) error {
+			return validateElems(ev, s, elemValidator)
 		},
 	)
 
 	return v
 }
 
+func (v *sliceValidator[S, E, V]) clone(base *baseValidator[S, SliceValidator[S, E, V]]) SliceValidator[S, E, V] {
+	c := *v
+	c.baseValidator = base
+
+	return &c
+}
+
 func (v *sliceValidator[S, E, V]) Empty() SliceValidator[S, E, V] {
-	v.checks = append(
-		v.checks,
+	return v.with(
+		Rule{Name: "empty"},
 		func(s S) error {
 			if len(s) != 0 {
-				return fmt.Errorf("expected %v to be empty", s)
+				return newError("empty", "expected %v to be empty", input(s))
 			}
 
 			return nil
 		},
 	)
-
-	return v
 }
 
 func (v *sliceValidator[S, E, V]) NotEmpty() SliceValidator[S, E, V] {
-	v.checks = append(
-		v.checks,
+	return v.with(
+		Rule{Name: "notEmpty"},
 		func(s S) error {
 			if len(s) == 0 {
-				return fmt.Errorf("expected %v not to be empty", s)
+				return newError("notEmpty", "expected %v not to be empty", input(s))
 			}
 
 			return nil
 		},
 	)
-
-	return v
 }
 
 func (v *sliceValidator[S, E, V]) Len(l int) SliceValidator[S, E, V] {
-	v.checks = append(
-		v.checks,
+	return v.withSpan(
+		Rule{Name: "len", Params: []any{l}},
+		sliceLen[S], l, l,
 		func(s S) error {
-			if len(s) == l {
-				return fmt.Errorf("expected %v to have len %d", s, l)
+			if len(s) != l {
+				return newError("len", "expected %v to have len %d", input(s), l)
 			}
 
 			return nil
 		},
 	)
-
-	return v
 }
 
 func (v *sliceValidator[S, E, V]) MinLen(min int) SliceValidator[S, E, V] {
-	v.checks = append(
-		v.checks,
+	return v.withSpan(
+		Rule{Name: "minLen", Params: []any{min}},
+		sliceLen[S], min, math.MaxInt,
 		func(s S) error {
 			if len(s) < min {
-				return fmt.Errorf("expected %v to have min len %d", s, min)
+				return newError("minLen", "expected %v to have min len %d", input(s), min)
 			}
 
 			return nil
 		},
 	)
-
-	return v
 }
 
 func (v *sliceValidator[S, E, V]) MaxLen(max int) SliceValidator[S, E, V] {
-	v.checks = append(
-		v.checks,
+	return v.withSpan(
+		Rule{Name: "maxLen", Params: []any{max}},
+		sliceLen[S], 0, max,
 		func(s S) error {
 			if len(s) > max {
-				return fmt.Errorf("expected %v to have max len %d", s, max)
+				return newError("maxLen", "expected %v to have max len %d", input(s), max)
 			}
 
 			return nil
 		},
 	)
-
-	return v
 }
 
 func (v *sliceValidator[S, E, V]) AllSatisfy(validator V) SliceValidator[S, E, V] {
-	v.checks = append(
-		v.checks,
-		func(s S) error {
-			for i, el := range s {
-				if err := validator.Validate(el); err != nil {
-					return fmt.Errorf("element at index %d did not satisfy validator: %v", i, err)
-				}
-			}
-
-			return nil
+	return v.withIn(
+		Rule{Name: "allSatisfy", Params: []any{validator}},
+		func(ev *Evaluation, s S) error {
+			return validateElems(ev, s, validator)
 		},
 	)
-
-	return v
 }
 
 func (v *sliceValidator[S, E, V]) AnySatisfy(validator V) SliceValidator[S, E, V] {
-	v.checks = append(
-		v.checks,
-		func(s S) error {
+	return v.withIn(
+		Rule{Name: "anySatisfy", Params: []any{validator}},
+		func(ev *Evaluation, s S) error {
+			probe := ev.probe()
 			for _, el := range s {
-				if err := validator.Validate(el); err == nil {
+				if err := validateIn[E](probe, validator, el); err == nil {
 					return nil
 				}
 			}
 
-			return fmt.Errorf("expected at least one element in %v to pass validator", s)
+			return newError("anySatisfy", "expected at least one element in %v to pass validator", input(s))
 		},
 	)
-	return v
 }
 
 func (v *sliceValidator[S, E, V]) NoneSatisfy(validator V) SliceValidator[S, E, V] {
-	v.checks = append(
-		v.checks,
-		func(s S) error {
+	return v.withIn(
+		Rule{Name: "noneSatisfy", Params: []any{validator}},
+		func(ev *Evaluation, s S) error {
+			probe := ev.probe()
 			for i, el := range s {
-				if err := validator.Validate(el); err == nil {
-					return fmt.Errorf("element at index %d passed validator: %v", i, err)
+				if err := validateIn[E](probe, validator, el); err == nil {
+					e := newError("noneSatisfy", "expected %v not to pass validator", input(el))
+					e.Path = indexPath(i)
+
+					return e
 				}
 			}
 
 			return nil
 		},
 	)
-
-	return v
 }
 
 func (v *sliceValidator[S, E, V]) ElemValidator() V {
 	return v.elemValidator
 }
+
+func (v *sliceValidator[S, E, V]) nested() []AnyValidator {
+	return []AnyValidator{v.elemValidator}
+}
+
+func (v *sliceValidator[S, E, V]) Describe() Description {
+	d := v.describe("slice")
+	elem := Describe(v.elemValidator)
+	d.Elem = &elem
+
+	return d
+}
+
+func validateElems[S ~[]E, E any, V Validator[E]](ev *Evaluation, s S, validator V) error {
+	var errs Errors
+	for i, el := range s {
+		ev.enter(indexPath(i))
+		err := validateIn[E](ev, validator, el)
+		ev.leave()
+
+		if err != nil {
+			errs = append(errs, prefixPath(indexPath(i), err)...)
+			if limited, full := ev.limit(errs); full {
+				return limited
+			}
+		}
+	}
+
+	if len(errs) == 0 {
+		return nil
+	}
+
+	return errs
+}
+
+func indexPath(i int) string {
+	return "[" + strconv.Itoa(i) + "]"
+}
@@ -1,9 +1,8 @@
 package validators
 
 import (
-	"fmt"
+	"math"
 	"regexp"
-	"slices"
 	"strings"
 
 	"github.com/google/uuid"
@@ -17,322 +16,300 @@ var _ StringValidator[string] = &stringValidator[string]{}
 
 func NewStringValidator[T ~string]() StringValidator[T] {
 	v := &stringValidator[T]{}
-	v.baseValidator = newBaseValidator[T, StringValidator[T]](v)
+	v.baseValidator = newBaseValidator[T, StringValidator[T]](v, v.clone)
 
 	return v
 }
 
+func (v *stringValidator[T]) clone(base *baseValidator[T, StringValidator[T]]) StringValidator[T] {
+	c := *v
+	c.baseValidator = base
+
+	return &c
+}
+
 func (v *stringValidator[T]) Empty() StringValidator[T] {
-	v.checks = append(
-		v.checks,
+	return v.with(
+		Rule{Name: "empty"},
 		func(t T) error {
 			if len(t) != 0 {
-				return fmt.Errorf("expected `%s` to not be empty", string(t))
+				return newError("empty", "expected `%s` to be empty", input(string(t)))
 			}
 
 			return nil
 		},
 	)
-
-	return v
 }
 
 func (v *stringValidator[T]) NotEmpty() StringValidator[T] {
-	v.checks = append(
-		v.checks,
+	return v.with(
+		Rule{Name: "notEmpty"},
 		func(t T) error {
 			if len(t) == 0 {
-				return fmt.Errorf("expected `%s` to not be empty", string(t))
+				return newError("notEmpty", "expected `%s` to not be empty", input(string(t)))
 			}
 
 			return nil
 		},
 	)
-
-	return v
 }
 
 func (v *stringValidator[T]) Len(l int) StringValidator[T] {
-	v.checks = append(
-		v.checks,
+	return v.withSpan(
+		Rule{Name: "len", Params: []any{l}},
+		stringLen[T], l, l,
 		func(t T) error {
 			if len(t) != l {
-				return fmt.Errorf("expected `%s` to have len %d, but got %d", string(t), l, len(t))
+				return newError("len", "expected `%s` to have len %d, but got %d", input(string(t)), l, len(t))
 			}
 
 			return nil
 		},
 	)
-
-	return v
 }
 
 func (v *stringValidator[T]) MinLen(min int) StringValidator[T] {
-	v.checks = append(
-		v.checks,
+	return v.withSpan(
+		Rule{Name: "minLen", Params: []any{min}},
+		stringLen[T], min, math.MaxInt,
 		func(t T) error {
 			if len(t) < min {
-				return fmt.Errorf("expected `%s` to have min len %d, but got %d", string(t), min, len(t))
+				return newError("minLen", "expected `%s` to have min len %d, but got %d", input(string(t)), min, len(t))
 			}
 
 			return nil
 		},
 	)
-
-	return v
 }
 
 func (v *stringValidator[T]) MaxLen(max int) StringValidator[T] {
-	v.checks = append(
-		v.checks,
+	return v.withSpan(
+		Rule{Name: "maxLen", Params: []any{max}},
+		stringLen[T], 0, max,
 		func(t T) error {
 			if len(t) > max {
-				return fmt.Errorf("expected `%s` to have max len %d, but got %d", string(t), max, len(t))
+				return newError("maxLen", "expected `%s` to have max len %d, but got %d", input(string(t)), max, len(t))
 			}
 
 			return nil
 		},
 	)
-
-	return v
 }
 
 func (v *stringValidator[T]) EqualTo(other T) StringValidator[T] {
-	v.checks = append(
-		v.checks,
+	return v.with(
+		Rule{Name: "equalTo", Params: []any{other}},
 		func(t T) error {
 			if t != other {
-				return fmt.Errorf("expected `%s` to equal `%s`", string(t), string(other))
+				return newError("equalTo", "expected `%s` to equal `%s`", input(string(t)), string(other))
 			}
 
 			return nil
 		},
 	)
-
-	return v
 }
 
 func (v *stringValidator[T]) NotEqualTo(other T) StringValidator[T] {
-	v.checks = append(
-		v.checks,
+	return v.with(
+		Rule{Name: "notEqualTo", Params: []any{other}},
 		func(t T) error {
 			if t == other {
-				return fmt.Errorf("expected `%s` not to equal `%s`", string(t), string(other))
+				return newError("notEqualTo", "expected `%s` not to equal `%s`", input(string(t)), bounded(string(other)))
 			}
 
 			return nil
 		},
 	)
-
-	return v
 }
 
 func (v *stringValidator[T]) HasPrefix(prefix T) StringValidator[T] {
-	v.checks = append(
-		v.checks,
+	return v.with(
+		Rule{Name: "hasPrefix", Params: []any{prefix}},
 		func(t T) error {
 			if !strings.HasPrefix(string(t), string(prefix)) {
-				return fmt.Errorf("expected `%s` to have prefix `%s`", string(t), string(prefix))
+				return newError("hasPrefix", "expected `%s` to have prefix `%s`", input(string(t)), string(prefix))
 			}
 
 			return nil
 		},
 	)
-
-	return v
 }
 
 func (v *stringValidator[T]) NotHasPrefix(prefix T) StringValidator[T] {
-	v.checks = append(
-		v.checks,
+	return v.with(
+		Rule{Name: "notHasPrefix", Params: []any{prefix}},
 		func(t T) error {
 			if strings.HasPrefix(string(t), string(prefix)) {
-				return fmt.Errorf("expected `%s` not to have prefix `%s`", string(t), string(prefix))
+				return newError("notHasPrefix", "expected `%s` not to have prefix `%s`", input(string(t)), bounded(string(prefix)))
 			}
 
 			return nil
 		},
 	)
-
-	return v
 }
 
 func (v *stringValidator[T]) HasSuffix(suffix T) StringValidator[T] {
-	v.checks = append(
-		v.checks,
+	return v.with(
+		Rule{Name: "hasSuffix", Params: []any{suffix}},
 		func(t T) error {
 			if !strings.HasSuffix(string(t), string(suffix)) {
-				return fmt.Errorf("expected `%s` to have suffix `%s`", string(t), string(suffix))
+				return newError("hasSuffix", "expected `%s` to have suffix `%s`", input(string(t)), string(suffix))
 			}
 
 			return nil
 		},
 	)
-
-	return v
 }
 
 func (v *stringValidator[T]) NotHasSuffix(suffix T) StringValidator[T] {
-	v.checks = append(
-		v.checks,
+	return v.with(
+		Rule{Name: "notHasSuffix", Params: []any{suffix}},
 		func(t T) error {
 			if strings.HasSuffix(string(t), string(suffix)) {
-				return fmt.Errorf("expected `%s` not to have suffix `%s`", string(t), string(suffix))
+				return newError("notHasSuffix", "expected `%s` not to have suffix `%s`", input(string(t)), bounded(string(suffix)))
 			}
 
 			return nil
 		},
 	)
-
-	return v
 }
 
 func (v *stringValidator[T]) Contains(needle T) StringValidator[T] {
-	v.checks = append(
-		v.checks,
+	return v.with(
+		Rule{Name: "contains", Params: []any{needle}},
 		func(t T) error {
 			if !strings.Contains(string(t), string(needle)) {
-				return fmt.Errorf("expected `%s` to contain `%s`", string(t), string(needle))
+				return newError("contains", "expected `%s` to contain `%s`", input(string(t)), string(needle))
 			}
 
 			return nil
 		},
 	)
-
-	return v
 }
 
 func (v *stringValidator[T]) NotContains(needle T) StringValidator[T] {
-	v.checks = append(
-		v.checks,
+	return v.with(
+		Rule{Name: "notContains", Params: []any{needle}},
 		func(t T) error {
 			if strings.Contains(string(t), string(needle)) {
-				return fmt.Errorf("expected `%s` not to contain `%s`", string(t), string(needle))
+				return newError("notContains", "expected `%s` not to contain `%s`", input(string(t)), bounded(string(needle)))
 			}
 
 			return nil
 		},
 	)
-
-	return v
 }
 
 func (v *stringValidator[T]) ContainsAtLeast(needle T, count int) StringValidator[T] {
-	v.checks = append(
-		v.checks,
+	// Counting stops at count instances, so that long values pass without
+	// being scanned in full.
+	return v.with(
+		Rule{Name: "containsAtLeast", Params: []any{needle, count}},
 		func(t T) error {
-			if strings.Count(string(t), string(needle)) < count {
-				return fmt.Errorf("expected `%s` to contain at least %d instances of `%s`", string(t), count, string(needle))
+			if countUpTo(string(t), string(needle), count) < count {
+				return newError("containsAtLeast", "expected `%s` to contain at least %d instances of `%s`", input(string(t)), count, string(needle))
 			}
 
 			return nil
 		},
 	)
-
-	return v
 }
 
 func (v *stringValidator[T]) ContainsAtMost(needle T, count int) StringValidator[T] {
-	v.checks = append(
-		v.checks,
+	return v.with(
+		Rule{Name: "containsAtMost", Params: []any{needle, count}},
 		func(t T) error {
 			if strings.Count(string(t), string(needle)) > count {
-				return fmt.Errorf("expected `%s` to contain at most %d instances of `%s`", string(t), count, string(needle))
+				return newError("containsAtMost", "expected `%s` to contain at most %d instances of `%s`", input(string(t)), count, bounded(string(needle)))
 			}
 
 			return nil
 		},
 	)
-
-	return v
 }
 
 func (v *stringValidator[T]) ContainsExact(needle T, count int) StringValidator[T] {
-	v.checks = append(
-		v.checks,
+	return v.with(
+		Rule{Name: "containsExact", Params: []any{needle, count}},
 		func(t T) error {
 			if strings.Count(string(t), string(needle)) != count {
-				return fmt.Errorf("expected `%s` to contain exactly %d instances of `%s`", string(t), count, string(needle))
+				return newError("containsExact", "expected `%s` to contain exactly %d instances of `%s`", input(string(t)), count, bounded(string(needle)))
 			}
 
 			return nil
 		},
 	)
-
-	return v
 }
 
 func (v *stringValidator[T]) In(haystack ...T) StringValidator[T] {
-	v.checks = append(
-		v.checks,
+	in := contains(haystack)
+
+	return v.with(
+		Rule{Name: "in", Params: params(haystack)},
 		func(t T) error {
-			if !slices.Contains(haystack, t) {
-				return fmt.Errorf("expected `%s` to be in (%#v)", string(t), haystack)
+			if !in(t) {
+				return newError("in", "expected `%s` to be in (%q)", input(string(t)), bounded(haystack))
 			}
 
 			return nil
 		},
 	)
-
-	return v
 }
 
 func (v *stringValidator[T]) NotIn(haystack ...T) StringValidator[T] {
-	v.checks = append(
-		v.checks,
+	in := contains(haystack)
+
+	return v.with(
+		Rule{Name: "notIn", Params: params(haystack)},
 		func(t T) error {
-			if slices.Contains(haystack, t) {
-				return fmt.Errorf("expected `%s` not to be in (%#v)", string(t), haystack)
+			if in(t) {
+				return newError("notIn", "expected `%s` not to be in (%q)", input(string(t)), bounded(haystack))
 			}
 
 			return nil
 		},
 	)
-
-	return v
 }
 
 func (v *stringValidator[T]) Matches(regex *regexp.Regexp) StringValidator[T] {
-	v.checks = append(
-		v.checks,
+	return v.with(
+		Rule{Name: "matches", Params: []any{regex}},
 		func(t T) error {
 			if !regex.MatchString(string(t)) {
-				return fmt.Errorf("expected `%s` to match regex `%s`", string(t), regex.String())
+				return newError("matches", "expected `%s` to match regex `%s`", input(string(t)), regex.String())
 			}
 
 			return nil
 		},
 	)
-
-	return v
 }
 
 func (v *stringValidator[T]) NotMatches(regex *regexp.Regexp) StringValidator[T] {
-	v.checks = append(
-		v.checks,
+	return v.with(
+		Rule{Name: "notMatches", Params: []any{regex}},
 		func(t T) error {
 			if regex.MatchString(string(t)) {
-				return fmt.Errorf("expected `%s` not to match regex `%s`", string(t), regex.String())
+				return newError("notMatches", "expected `%s` not to match regex `%s`", input(string(t)), regex.String())
 			}
 
 			return nil
 		},
 	)
-
-	return v
 }
 
 func (v *stringValidator[T]) ValidUUID() StringValidator[T] {
-	v.checks = append(
-		v.checks,
+	return v.with(
+		Rule{Name: "uuid"},
 		func(t T) error {
 			if _, err := uuid.Parse(string(t)); err != nil {
-				return fmt.Errorf("expected `%s` to be a valid uuid: %v", string(t), err)
+				return newError("uuid", "expected `%s` to be a valid uuid: %v", input(string(t)), err)
 			}
 
 			return nil
 		},
 	)
+}
 
-	return v
+func (v *stringValidator[T]) Describe() Description {
+	return v.describe("string")
 }
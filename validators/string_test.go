@@ -2,271 +2,211 @@ package validators_test
 
 import (
 	"regexp"
+	"slices"
 	"testing"
 
 	"github.com/bitcrshr/valid/validators"
+	"github.com/bitcrshr/valid/validtest"
 	"github.com/google/uuid"
 )
 
+func fails(code string) []validtest.Violation {
+	return []validtest.Violation{{Code: code}}
+}
+
 func TestStringValidator(t *testing.T) {
-	cases := []struct {
+	tests := []struct {
 		v     validators.StringValidator[string]
-		tests []struct {
-			str  string
-			pass bool
-		}
+		cases validtest.Cases[string]
 	}{
 		{
 			v: validators.NewStringValidator[string]().Empty(),
-			tests: []struct {
-				str  string
-				pass bool
-			}{
-				{str: "", pass: true},
-				{str: "foo", pass: false},
+			cases: validtest.Cases[string]{
+				{Value: ""},
+				{Value: "foo", Want: fails("empty")},
 			},
 		},
 
 		{
 			v: validators.NewStringValidator[string]().NotEmpty(),
-			tests: []struct {
-				str  string
-				pass bool
-			}{
-				{str: "", pass: false},
-				{str: "foo", pass: true},
+			cases: validtest.Cases[string]{
+				{Value: "", Want: fails("notEmpty")},
+				{Value: "foo"},
 			},
 		},
 
 		{
 			v: validators.NewStringValidator[string]().Len(5),
-			tests: []struct {
-				str  string
-				pass bool
-			}{
-				{str: "hello", pass: true},
-				{str: "foo", pass: false},
-				{str: "", pass: false},
-				{str: "ohgreatheavens", pass: false},
+			cases: validtest.Cases[string]{
+				{Value: "hello"},
+				{Value: "foo", Want: fails("len")},
+				{Value: "", Want: fails("len")},
+				{Value: "ohgreatheavens", Want: fails("len")},
 			},
 		},
 
 		{
 			v: validators.NewStringValidator[string]().MinLen(2),
-			tests: []struct {
-				str  string
-				pass bool
-			}{
-				{str: "hello", pass: true},
-				{str: "foo", pass: true},
-				{str: "", pass: false},
-				{str: "ohgreatheavens", pass: true},
-				{str: "E", pass: false},
+			cases: validtest.Cases[string]{
+				{Value: "hello"},
+				{Value: "foo"},
+				{Value: "", Want: fails("minLen")},
+				{Value: "ohgreatheavens"},
+				{Value: "E", Want: fails("minLen")},
 			},
 		},
 
 		{
 			v: validators.NewStringValidator[string]().MaxLen(2),
-			tests: []struct {
-				str  string
-				pass bool
-			}{
-				{str: "hello", pass: false},
-				{str: "foo", pass: false},
-				{str: "", pass: true},
-				{str: "ohgreatheavens", pass: false},
-				{str: "EE", pass: true},
+			cases: validtest.Cases[string]{
+				{Value: "hello", Want: fails("maxLen")},
+				{Value: "foo", Want: fails("maxLen")},
+				{Value: ""},
+				{Value: "ohgreatheavens", Want: fails("maxLen")},
+				{Value: "EE"},
 			},
 		},
 
 		{
 			v: validators.NewStringValidator[string]().EqualTo("yeet"),
-			tests: []struct {
-				str  string
-				pass bool
-			}{
-				{str: "yeet", pass: true},
-				{str: "yoink", pass: false},
+			cases: validtest.Cases[string]{
+				{Value: "yeet"},
+				{Value: "yoink", Want: fails("equalTo")},
 			},
 		},
 
 		{
 			v: validators.NewStringValidator[string]().NotEqualTo("yeet"),
-			tests: []struct {
-				str  string
-				pass bool
-			}{
-				{str: "yeet", pass: false},
-				{str: "yoink", pass: true},
+			cases: validtest.Cases[string]{
+				{Value: "yeet", Want: fails("notEqualTo")},
+				{Value: "yoink"},
 			},
 		},
 
 		{
 			v: validators.NewStringValidator[string]().HasPrefix("oog"),
-			tests: []struct {
-				str  string
-				pass bool
-			}{
-				{str: "ooga booga", pass: true},
-				{str: "yoink", pass: false},
+			cases: validtest.Cases[string]{
+				{Value: "ooga booga"},
+				{Value: "yoink", Want: fails("hasPrefix")},
 			},
 		},
 
 		{
 			v: validators.NewStringValidator[string]().NotHasPrefix("oog"),
-			tests: []struct {
-				str  string
-				pass bool
-			}{
-				{str: "ooga booga", pass: false},
-				{str: "yoink", pass: true},
+			cases: validtest.Cases[string]{
+				{Value: "ooga booga", Want: fails("notHasPrefix")},
+				{Value: "yoink"},
 			},
 		},
 
 		{
 			v: validators.NewStringValidator[string]().HasSuffix("ooga"),
-			tests: []struct {
-				str  string
-				pass bool
-			}{
-				{str: "ooga booga", pass: true},
-				{str: "yoink", pass: false},
+			cases: validtest.Cases[string]{
+				{Value: "ooga booga"},
+				{Value: "yoink", Want: fails("hasSuffix")},
 			},
 		},
 
 		{
 			v: validators.NewStringValidator[string]().NotHasSuffix("ooga"),
-			tests: []struct {
-				str  string
-				pass bool
-			}{
-				{str: "ooga booga", pass: false},
-				{str: "yoink", pass: true},
+			cases: validtest.Cases[string]{
+				{Value: "ooga booga", Want: fails("notHasSuffix")},
+				{Value: "yoink"},
 			},
 		},
 
 		{
 			v: validators.NewStringValidator[string]().Contains("a b"),
-			tests: []struct {
-				str  string
-				pass bool
-			}{
-				{str: "ooga booga", pass: true},
-				{str: "yoink", pass: false},
+			cases: validtest.Cases[string]{
+				{Value: "ooga booga"},
+				{Value: "yoink", Want: fails("contains")},
 			},
 		},
 
 		{
 			v: validators.NewStringValidator[string]().NotContains("a b"),
-			tests: []struct {
-				str  string
-				pass bool
-			}{
-				{str: "ooga booga", pass: false},
-				{str: "yoink", pass: true},
+			cases: validtest.Cases[string]{
+				{Value: "ooga booga", Want: fails("notContains")},
+				{Value: "yoink"},
 			},
 		},
 
 		{
 			v: validators.NewStringValidator[string]().ContainsAtLeast("a b", 2),
-			tests: []struct {
-				str  string
-				pass bool
-			}{
-				{str: "ooga booga b", pass: true},
-				{str: "yoink", pass: false},
+			cases: validtest.Cases[string]{
+				{Value: "ooga booga b"},
+				{Value: "yoink", Want: fails("containsAtLeast")},
 			},
 		},
 
 		{
 			v: validators.NewStringValidator[string]().ContainsAtMost("a b", 2),
-			tests: []struct {
-				str  string
-				pass bool
-			}{
-				{str: "ooga booga b", pass: true},
-				{str: "ooga booga", pass: true},
-				{str: "ooga booga b a b", pass: false},
-				{str: "yoink", pass: true},
+			cases: validtest.Cases[string]{
+				{Value: "ooga booga b"},
+				{Value: "ooga booga"},
+				{Value: "ooga booga b a b", Want: fails("containsAtMost")},
+				{Value: "yoink"},
 			},
 		},
 
 		{
 			v: validators.NewStringValidator[string]().ContainsExact("a b", 2),
-			tests: []struct {
-				str  string
-				pass bool
-			}{
-				{str: "ooga booga b", pass: true},
-				{str: "ooga booga", pass: false},
-				{str: "ooga booga b a b", pass: false},
-				{str: "yoink", pass: false},
+			cases: validtest.Cases[string]{
+				{Value: "ooga booga b"},
+				{Value: "ooga booga", Want: fails("containsExact")},
+				{Value: "ooga booga b a b", Want: fails("containsExact")},
+				{Value: "yoink", Want: fails("containsExact")},
 			},
 		},
 
 		{
 			v: validators.NewStringValidator[string]().In("abc", "def", "ghi"),
-			tests: []struct {
-				str  string
-				pass bool
-			}{
-				{str: "abc", pass: true},
-				{str: "", pass: false},
-				{str: "yoink", pass: false},
+			cases: validtest.Cases[string]{
+				{Value: "abc"},
+				{Value: "", Want: fails("in")},
+				{Value: "yoink", Want: fails("in")},
 			},
 		},
 
 		{
 			v: validators.NewStringValidator[string]().NotIn("abc", "def", "ghi"),
-			tests: []struct {
-				str  string
-				pass bool
-			}{
-				{str: "jkl", pass: true},
-				{str: "abc", pass: false},
-				{str: "", pass: true},
-				{str: "yoink", pass: true},
+			cases: validtest.Cases[string]{
+				{Value: "jkl"},
+				{Value: "abc", Want: fails("notIn")},
+				{Value: ""},
+				{Value: "yoink"},
 			},
 		},
 
 		{
 			v: validators.NewStringValidator[string]().Matches(regexp.MustCompile("^[a-z]+$")),
-			tests: []struct {
-				str  string
-				pass bool
-			}{
-				{str: "jkl", pass: true},
-				{str: "abc", pass: true},
-				{str: "", pass: false},
-				{str: "yoink", pass: true},
-				{str: "8675309", pass: false},
+			cases: validtest.Cases[string]{
+				{Value: "jkl"},
+				{Value: "abc"},
+				{Value: "", Want: fails("matches")},
+				{Value: "yoink"},
+				{Value: "8675309", Want: fails("matches")},
 			},
 		},
 
 		{
 			v: validators.NewStringValidator[string]().NotMatches(regexp.MustCompile("^[a-z]+$")),
-			tests: []struct {
-				str  string
-				pass bool
-			}{
-				{str: "jkl", pass: false},
-				{str: "abc", pass: false},
-				{str: "", pass: true},
-				{str: "yoink", pass: false},
-				{str: "8675309", pass: true},
+			cases: validtest.Cases[string]{
+				{Value: "jkl", Want: fails("notMatches")},
+				{Value: "abc", Want: fails("notMatches")},
+				{Value: ""},
+				{Value: "yoink", Want: fails("notMatches")},
+				{Value: "8675309"},
 			},
 		},
 
 		{
 			v: validators.NewStringValidator[string]().ValidUUID(),
-			tests: []struct {
-				str  string
-				pass bool
-			}{
-				{str: uuid.NewString(), pass: true},
-				{str: uuid.Nil.String(), pass: true},
-				{str: "abc", pass: false},
-				{str: "", pass: false},
+			cases: validtest.Cases[string]{
+				{Value: uuid.NewString()},
+				{Value: uuid.Nil.String()},
+				{Value: "abc", Want: fails("uuid")},
+				{Value: "", Want: fails("uuid")},
 			},
 		},
 
@@ -279,44 +219,117 @@ func TestStringValidator(t *testing.T) {
 				NotEmpty().
 				HasPrefix("ba").
 				NotHasSuffix("az"),
-
-			tests: []struct {
-				str  string
-				pass bool
-			}{
-				{str: "bar", pass: true},
-				{str: "baz", pass: false},
-				{str: "bazinga", pass: true},
-				{str: "", pass: false},
-				{str: "hello, world!", pass: false},
+			cases: validtest.Cases[string]{
+				{Value: "bar"},
+				{Value: "baz", Want: fails("notHasSuffix")},
+				{Value: "bazinga"},
+				{Value: "", Want: fails("minLen")},
+				{Value: "hello, world!", Want: fails("maxLen")},
 			},
 		},
 
 		{
 			v: validators.NewStringValidator[string]().ValidUUID(),
-			tests: []struct {
-				str  string
-				pass bool
-			}{
-				{str: uuid.NewString(), pass: true},
-				{str: uuid.Nil.String(), pass: true},
-				{str: "abc", pass: false},
-				{str: "", pass: false},
+			cases: validtest.Cases[string]{
+				{Value: uuid.NewString()},
+				{Value: uuid.Nil.String()},
+				{Value: "abc", Want: fails("uuid")},
+				{Value: "", Want: fails("uuid")},
 			},
 		},
 	}
 
-	for _, c := range cases {
-		for _, test := range c.tests {
-			err := c.v.Validate(test.str)
+	for _, test := range tests {
+		test.cases.Run(t, test.v)
+	}
+}
+
+func TestStringValidatorCompiledRules(t *testing.T) {
+	colors := []string{"red", "orange", "yellow", "green", "blue", "indigo", "violet", "black", "white"}
+
+	validtest.Cases[string]{
+		{Name: "in set", Value: "indigo"},
+		{Name: "not in set", Value: "grey", Want: fails("in")},
+	}.Run(t, validators.NewStringValidator[string]().In(colors...))
+
+	validtest.Cases[string]{
+		{Name: "not in set", Value: "grey"},
+		{Name: "in set", Value: "white", Want: fails("notIn")},
+	}.Run(t, validators.NewStringValidator[string]().NotIn(colors...))
+
+	// Adjacent length checks are fused, but still report the rule that is
+	// violated, with its params.
+	fused := validators.NewStringValidator[string]().MinLen(3).MaxLen(5).Len(4)
+	validtest.Cases[string]{
+		{Name: "in range", Value: "abcd"},
+		{Name: "too short", Value: "ab", Want: fails("minLen")},
+		{Name: "too long", Value: "abcdef", Want: fails("maxLen")},
+		{Name: "wrong len", Value: "abc", Want: fails("len")},
+	}.Run(t, fused)
+
+	if errs := validators.AsErrors(fused.Validate("ab")); len(errs) != 1 || !slices.Equal(errs[0].Params, []any{3}) {
+		t.Errorf("expected minLen to fail with params [3], got %v", errs)
+	}
+
+	grouped := validators.NewStringValidator[string]().MinLen(3).MaxLen(5).Groups("create")
+	if err := grouped.Validate("abcdef"); err != nil {
+		t.Errorf("expected maxLen outside of its group to be skipped, got %v", err)
+	}
+
+	if err := grouped.ValidateGroups("abcdef", "create"); err == nil {
+		t.Error("expected maxLen in its group to fail")
+	}
+
+	warned := validators.NewStringValidator[string]().MinLen(3).MaxLen(5).AsWarning()
+	if res := warned.Check("abcdef"); len(res.Errors) != 0 || len(res.Warnings) != 1 || res.Warnings[0].Code != "maxLen" {
+		t.Errorf("expected maxLen to warn, got %v", res)
+	}
 
-			if test.pass && err != nil {
-				t.Errorf("Expected `%s` to pass", test.str)
-			}
+	validtest.Cases[string]{
+		{Name: "at least", Value: "aaaa"},
+		{Name: "overlapping", Value: "aaa", Want: fails("containsAtLeast")},
+	}.Run(t, validators.NewStringValidator[string]().ContainsAtLeast("aa", 2))
+
+	validtest.Cases[string]{
+		{Name: "at most", Value: "abab"},
+		{Name: "too many", Value: "ababab", Want: fails("containsAtMost")},
+	}.Run(t, validators.NewStringValidator[string]().ContainsAtMost("ab", 2))
+
+	validtest.Cases[string]{
+		{Name: "empty needle", Value: "ab"},
+		{Name: "empty needle too many", Value: "abc", Want: fails("containsExact")},
+	}.Run(t, validators.NewStringValidator[string]().ContainsExact("", 3))
+}
+
+func TestStringValidatorSharedBase(t *testing.T) {
+	base := validators.NewStringValidator[string]().NotEmpty()
+	short := base.MaxLen(5)
+	long := base.MaxLen(50)
+
+	validtest.Cases[string]{
+		{Name: "base", Value: "hello, world!"},
+		{Name: "base empty", Value: "", Want: fails("notEmpty")},
+	}.Run(t, base)
+
+	validtest.Cases[string]{
+		{Name: "short", Value: "hello"},
+		{Name: "short too long", Value: "hello, world!", Want: fails("maxLen")},
+	}.Run(t, short)
+
+	validtest.Cases[string]{
+		{Name: "long", Value: "hello, world!"},
+		{Name: "long empty", Value: "", Want: fails("notEmpty")},
+	}.Run(t, long)
+
+	// Appending to a validator derived twice must not overwrite the rules of
+	// its siblings, even when the underlying array has spare capacity.
+	a := base.MinLen(2).HasPrefix("a")
+	b := base.MinLen(2).HasPrefix("b")
+	if err := a.Validate("ab"); err != nil {
+		t.Errorf("expected ab to pass, got %v", err)
+	}
 
-			if !test.pass && err == nil {
-				t.Errorf("Expected `%s` to fail", test.str)
-			}
-		}
+	if err := b.Validate("ba"); err != nil {
+		t.Errorf("expected ba to pass, got %v", err)
 	}
 }
@@ -1,8 +1,12 @@
 package validators
 
 import (
+	"errors"
 	"fmt"
+	"maps"
 	"reflect"
+	"slices"
+	"strings"
 )
 
 type StructShape map[string]AnyValidator
@@ -10,47 +14,402 @@ type StructShape map[string]AnyValidator
 type StructValidator[T any] struct {
 	*baseValidator[T, *StructValidator[T]]
 	shape StructShape
+	opts  []StructOption
+	// keys are the keys of shape, sorted, in the order fields are validated.
+	keys []string
+	// fields holds the resolved field of every key of shape, unless T is an
+	// interface type and no type was set with ForType.
+	fields map[string]structField
+	// flat is set if every field is validated as a scalar, see validateFlat.
+	flat bool
+	// typ is the type set with ForType, which values must be of.
+	typ reflect.Type
 }
 
-func NewStructValidator[T any](shape StructShape) *StructValidator[T] {
+// structField is a field of a struct validator, resolved when it is built.
+type structField struct {
+	path fieldPath
+	// scalar is the validator of the field if it validates strings or
+	// numbers, see validateFlat.
+	scalar scalarValidator
+}
+
+var _ RuleValidator = &StructValidator[struct{}]{}
+
+// NewStructValidator returns a validator that validates the fields of T with
+// the validators in shape. It fails if a key of shape is not an exported field
+// of T, or if the validator for a field does not accept values of the field's
+// type, listing every mismatch in the returned error. If T is an interface
+// type, fields are only looked up when values are validated, see ForType.
+//
+// Keys may name fields promoted from embedded structs, and may be dotted
+// paths like "Address.City" into nested structs, following pointers. If a
+// pointer along a path is nil, the field is absent and its validator is not
+// run; require the pointer to be set with a validator for it, e.g.
+// "Address": valid.Pointer(...).NotNil(), if it must be present.
+//
+// Options enable further checks, see Exhaustive.
+func NewStructValidator[T any](shape StructShape, opts ...StructOption) (*StructValidator[T], error) {
+	var o structOptions
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	t, err := o.structType(reflect.TypeFor[T]())
+	if err != nil {
+		return nil, err
+	}
+
+	fields, err := checkShape(t, shape)
+	if err := errors.Join(err, o.checkCoverage(t, shape)); err != nil {
+		return nil, err
+	}
+
 	v := &StructValidator[T]{
-		shape: shape,
+		shape:  maps.Clone(shape),
+		opts:   slices.Clip(opts),
+		keys:   slices.Sorted(maps.Keys(shape)),
+		fields: fields,
+		flat:   fields != nil,
+		typ:    o.typ,
+	}
+	for _, f := range fields {
+		v.flat = v.flat && f.scalar != nil
+	}
+
+	v.baseValidator = newBaseValidator(v, v.clone)
+
+	v.check(v.validateFields)
+
+	return v, nil
+}
+
+// MustStructValidator is like NewStructValidator but panics if shape does not
+// match T.
+func MustStructValidator[T any](shape StructShape, opts ...StructOption) *StructValidator[T] {
+	v, err := NewStructValidator[T](shape, opts...)
+	if err != nil {
+		panic(err)
 	}
-	v.baseValidator = newBaseValidator(v)
 
 	return v
 }
 
+func (v *StructValidator[T]) clone(base *baseValidator[T, *StructValidator[T]]) *StructValidator[T] {
+	c := *v
+	c.baseValidator = base
+
+	return &c
+}
+
 func (v *StructValidator[T]) Zero() *StructValidator[T] {
-	v.checks = append(
-		v.checks,
+	return v.with(
+		Rule{Name: "zero"},
 		func(t T) error {
-			if !reflect.ValueOf(t).IsZero() {
-				return fmt.Errorf("expected %v to be zero value of %T", t, t)
+			if !isZero(t) {
+				return newError("zero", "expected %v to be zero value of %T", input(t), t)
 			}
 
 			return nil
 		},
 	)
-
-	return v
 }
 
 func (v *StructValidator[T]) NotZero() *StructValidator[T] {
-	v.checks = append(
-		v.checks,
+	return v.with(
+		Rule{Name: "notZero"},
 		func(t T) error {
-			if reflect.ValueOf(t).IsZero() {
-				return fmt.Errorf("expected %v to not be zero value of %T", t, t)
+			if isZero(t) {
+				return newError("notZero", "expected %v to not be zero value of %T", input(t), t)
 			}
 
 			return nil
 		},
 	)
+}
 
-	return v
+// isZero reports whether t is the zero value of its type. A nil value of an
+// interface type is zero.
+func isZero(t any) bool {
+	rv := reflect.ValueOf(t)
+
+	return !rv.IsValid() || rv.IsZero()
 }
 
+// Shape returns a copy of the validators of the fields. Modifying it does not
+// affect v; use Extend to derive a validator with a different shape.
 func (v *StructValidator[T]) Shape() StructShape {
-	return v.shape
+	return maps.Clone(v.shape)
+}
+
+// Extend returns a new validator whose shape is that of v with the entries of
+// shape added, replacing those for the same fields. The rules of v are kept,
+// and so are Sensitive and MaxErrors. v itself is left unchanged, and so are
+// the options it was built with. Like MustStructValidator, it panics if the
+// merged shape does not match T.
+func (v *StructValidator[T]) Extend(shape StructShape) *StructValidator[T] {
+	v.mustNotBeSealed("extend")
+
+	merged := maps.Clone(v.shape)
+	maps.Copy(merged, shape)
+
+	ext := MustStructValidator[T](merged, v.opts...)
+	ext.sensitive, ext.maxErrors = v.sensitive, v.maxErrors
+	for _, r := range v.rules {
+		if r.Name != "" {
+			ext.rules = append(ext.rules, r)
+		}
+	}
+
+	ext.compiled = fuse(ext.rules)
+
+	return ext
+}
+
+func (v *StructValidator[T]) nested() []AnyValidator {
+	return slices.Collect(maps.Values(v.shape))
+}
+
+func (v *StructValidator[T]) Describe() Description {
+	d := v.describe("struct")
+	d.Fields = make(map[string]Description, len(v.shape))
+	for name, fv := range v.shape {
+		d.Fields[name] = Describe(fv)
+	}
+
+	return d
+}
+
+// validateFields runs every validator in the shape against its field and
+// reports all violations, sorted by field path.
+func (v *StructValidator[T]) validateFields(ev *Evaluation, t T) error {
+	if v.flat {
+		return v.validateFlat(ev, t)
+	}
+
+	return v.validateShape(ev, t, nil, false)
+}
+
+// validateFlat is validateFields for structs whose fields are all validated
+// as scalars. As no field is boxed, it does not allocate unless a field is
+// invalid.
+func (v *StructValidator[T]) validateFlat(ev *Evaluation, t T) error {
+	if v.typ != nil && reflect.TypeOf(t) != v.typ {
+		return newError(CodeType, "expected value of type %s, but found %T", v.typ, t)
+	}
+
+	rv := reflect.ValueOf(t)
+
+	var errs Errors
+	for _, key := range v.keys {
+		f := v.fields[key]
+		field, ok := f.path.value(rv)
+		if !ok {
+			continue
+		}
+
+		ev.enter(key)
+		err := f.scalar.validateScalarIn(ev, scalarOf(field))
+		ev.leave()
+
+		if err != nil {
+			errs = append(errs, prefixPath(key, err)...)
+			if limited, full := ev.limit(errs); full {
+				return limited
+			}
+		}
+	}
+
+	if len(errs) == 0 {
+		return nil
+	}
+
+	return errs
+}
+
+// validateShape runs the validators in the shape against their fields. If
+// partial is set, only the entries selected by fields are run, see
+// ValidatePartial.
+func (v *StructValidator[T]) validateShape(ev *Evaluation, t T, fields []string, partial bool) error {
+	if v.typ != nil && reflect.TypeOf(t) != v.typ {
+		return newError(CodeType, "expected value of type %s, but found %T", v.typ, t)
+	}
+
+	rv := reflect.ValueOf(t)
+	if !rv.IsValid() && len(v.keys) > 0 {
+		// T is an interface type and t is nil, so there are no fields.
+		return newError(CodeType, "expected a struct, but found %v", t)
+	}
+
+	var errs Errors
+	for _, key := range v.keys {
+		all, rest := true, []string(nil)
+		if partial {
+			if all, rest = selectField(fields, key); !all && rest == nil {
+				continue
+			}
+		}
+
+		f, ok := v.fields[key]
+		path := f.path
+		if !ok {
+			// T is an interface type, so fields are resolved against the
+			// dynamic type of the value.
+			var err error
+			if path, _, err = resolveField(rv.Type(), key); err != nil {
+				e := newError(CodeField, "expected %T to have field %s: %v", t, key, err)
+				e.Path = key
+				errs = append(errs, e)
+
+				continue
+			}
+		}
+
+		field, ok := path.value(rv)
+		if !ok {
+			continue
+		}
+
+		fv := v.shape[key]
+
+		var err error
+		ev.enter(key)
+		if p, ok := fv.(partialValidator); ok && !all {
+			err = p.validatePartialAny(ev, field.Interface(), rest)
+		} else {
+			err = validateAnyIn(ev, fv, field.Interface())
+		}
+
+		ev.leave()
+
+		if err != nil {
+			errs = append(errs, prefixPath(key, err)...)
+			if limited, full := ev.limit(errs); full {
+				return limited
+			}
+		}
+	}
+
+	if len(errs) == 0 {
+		return nil
+	}
+
+	return errs
+}
+
+// fieldPath holds the index of each field along a dotted path, as used by
+// reflect.Value.FieldByIndex.
+type fieldPath [][]int
+
+// resolveField looks up key, the name of a field, possibly promoted from an
+// embedded struct, or a dotted path of such names, in t. Pointers to structs
+// along the path are followed.
+func resolveField(t reflect.Type, key string) (fieldPath, reflect.Type, error) {
+	var path fieldPath
+	for name := range strings.SplitSeq(key, ".") {
+		for t.Kind() == reflect.Pointer {
+			t = t.Elem()
+		}
+
+		if t.Kind() != reflect.Struct {
+			return nil, nil, fmt.Errorf("%s is not a struct", t)
+		}
+
+		field, ok := t.FieldByName(name)
+		if !ok || !field.IsExported() {
+			return nil, nil, fmt.Errorf("%s has no exported field %s", t, name)
+		}
+
+		// Fields promoted through unexported embedded structs cannot be
+		// read by reflection.
+		for i := 1; i < len(field.Index); i++ {
+			if embedded := t.FieldByIndex(field.Index[:i]); !embedded.IsExported() {
+				return nil, nil, fmt.Errorf("%s.%s is promoted through unexported field %s", t, name, embedded.Name)
+			}
+		}
+
+		path = append(path, field.Index)
+		t = field.Type
+	}
+
+	return path, t, nil
+}
+
+// value returns the field at p in rv. It reports false if a pointer along the
+// way, including an embedded one, is nil, in which case the field is absent.
+func (p fieldPath) value(rv reflect.Value) (reflect.Value, bool) {
+	for _, index := range p {
+		for rv.Kind() == reflect.Pointer {
+			if rv.IsNil() {
+				return reflect.Value{}, false
+			}
+
+			rv = rv.Elem()
+		}
+
+		field, err := rv.FieldByIndexErr(index)
+		if err != nil {
+			return reflect.Value{}, false
+		}
+
+		rv = field
+	}
+
+	return rv, true
+}
+
+// checkShape resolves every key of shape in t. It reports keys that do not
+// refer to an exported field, and validators that do not accept values of
+// their field's type.
+func checkShape(t reflect.Type, shape StructShape) (map[string]structField, error) {
+	if t.Kind() == reflect.Interface {
+		return nil, nil
+	}
+
+	if t.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("validators: %s is not a struct", t)
+	}
+
+	fields := make(map[string]structField, len(shape))
+
+	var errs []error
+	for _, key := range slices.Sorted(maps.Keys(shape)) {
+		path, ft, err := resolveField(t, key)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("validators: %s.%s: %w", t, key, err))
+			continue
+		}
+
+		fv := shape[key]
+		if fv == nil {
+			errs = append(errs, fmt.Errorf("validators: %s.%s: validator is nil", t, key))
+			continue
+		}
+
+		if vt := valueType(fv); vt != nil && !accepts(vt, ft) {
+			errs = append(errs, fmt.Errorf("validators: %s.%s: %T validates %s, not %s", t, key, fv, vt, ft))
+			continue
+		}
+
+		fields[key] = structField{path: path, scalar: scalarFor(fv, ft)}
+	}
+
+	return fields, errors.Join(errs...)
+}
+
+// accepts reports whether a validator of values of type vt may be given
+// values of type t by ValidateAny. For fields of interface type, this depends
+// on the dynamic type of the value, so it is only ruled out if vt cannot be
+// stored in t.
+func accepts(vt, t reflect.Type) bool {
+	switch {
+	case t == vt:
+		return true
+	case vt.Kind() == reflect.Interface:
+		return t.Implements(vt)
+	case t.Kind() == reflect.Interface:
+		return vt.Implements(t)
+	}
+
+	return false
 }
@@ -1,9 +1,12 @@
 package validators_test
 
 import (
+	"reflect"
+	"strings"
 	"testing"
 
 	"github.com/bitcrshr/valid/validators"
+	"github.com/bitcrshr/valid/validtest"
 )
 
 func TestStructValidator(t *testing.T) {
@@ -12,7 +15,7 @@ func TestStructValidator(t *testing.T) {
 		Baz int
 	}
 
-	v := validators.NewStructValidator[Foo](validators.StructShape{
+	v := validators.MustStructValidator[Foo](validators.StructShape{
 		"Bar": validators.NewStringValidator[string]().Contains("ooga"),
 		"Baz": validators.NewNumberValidator[int]().Positive(),
 	})
@@ -38,3 +41,278 @@ func TestStructValidator(t *testing.T) {
 		t.Errorf("expected %#v to fail", foo4)
 	}
 }
+
+func TestStructValidatorExtend(t *testing.T) {
+	type Foo struct {
+		Bar string
+		Baz int
+	}
+
+	base := validators.MustStructValidator[Foo](validators.StructShape{
+		"Bar": validators.NewStringValidator[string]().NotEmpty(),
+	}).NotZero()
+
+	ext := base.Extend(validators.StructShape{
+		"Baz": validators.NewNumberValidator[int]().Positive(),
+	})
+
+	foo := Foo{Bar: "bar", Baz: -1}
+	if err := base.Validate(foo); err != nil {
+		t.Errorf("expected %#v to pass base, got %v", foo, err)
+	}
+
+	validtest.AssertInvalid(t, ext, foo, "Baz", "positive")
+	if rules := ext.Rules(); len(rules) != 1 || rules[0].Name != "notZero" {
+		t.Errorf("expected Extend to keep the rules of the base, got %v", rules)
+	}
+
+	if _, ok := base.Shape()["Baz"]; ok {
+		t.Errorf("expected Extend not to modify the base shape")
+	}
+
+	base.Shape()["Baz"] = validators.NewNumberValidator[int]().Positive()
+	if err := base.Validate(foo); err != nil {
+		t.Errorf("expected modifying the returned shape not to affect the validator, got %v", err)
+	}
+}
+
+func TestStructValidatorSharedBase(t *testing.T) {
+	type Foo struct {
+		Bar string
+	}
+
+	base := validators.MustStructValidator[Foo](validators.StructShape{
+		"Bar": validators.NewStringValidator[string](),
+	})
+	nonZero := base.NotZero()
+
+	if err := base.Validate(Foo{}); err != nil {
+		t.Errorf("expected NotZero not to modify the base, got %v", err)
+	}
+
+	validtest.AssertInvalid(t, nonZero, Foo{}, "", "notZero")
+}
+
+func TestNewStructValidatorShapeMismatch(t *testing.T) {
+	type Foo struct {
+		Bar string
+		Baz int
+		Qux error
+		qux string
+	}
+
+	_, err := validators.NewStructValidator[Foo](validators.StructShape{
+		"Nmae": validators.NewStringValidator[string](),
+		"Baz":  validators.NewStringValidator[string](),
+		"Bar":  validators.NewNumberValidator[int](),
+		"qux":  validators.NewStringValidator[string](),
+	})
+	if err == nil {
+		t.Fatal("expected mismatched shape to fail")
+	}
+
+	for _, want := range []string{
+		"Foo.Bar: ",
+		"validates int, not string",
+		"Foo.Baz: ",
+		"validates string, not int",
+		"has no exported field Nmae",
+		"has no exported field qux",
+	} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("expected error to contain %q, got:\n%v", want, err)
+		}
+	}
+
+	if _, err := validators.NewStructValidator[string](nil); err == nil {
+		t.Errorf("expected non-struct type to fail")
+	}
+
+	if _, err := validators.NewStructValidator[Foo](validators.StructShape{
+		"Qux": validators.NewStringValidator[string](),
+	}); err == nil {
+		t.Errorf("expected validator of string for field of type error to fail")
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Errorf("expected MustStructValidator to panic")
+		}
+	}()
+
+	validators.MustStructValidator[Foo](validators.StructShape{"Nmae": validators.NewStringValidator[string]()})
+}
+
+func TestStructValidatorFieldPaths(t *testing.T) {
+	type Geo struct {
+		Lat float64
+	}
+
+	type Address struct {
+		City string
+		Geo  *Geo
+	}
+
+	type Timestamps struct {
+		CreatedAt int64
+	}
+
+	type User struct {
+		*Timestamps
+		Name    string
+		Address *Address
+	}
+
+	v := validators.MustStructValidator[User](validators.StructShape{
+		"CreatedAt":       validators.NewNumberValidator[int64]().Positive(),
+		"Address.City":    validators.NewStringValidator[string]().NotEmpty(),
+		"Address.Geo.Lat": validators.NewNumberValidator[float64]().GTE(-90).LTE(90),
+	})
+
+	validtest.Cases[User]{
+		{Name: "nil pointers", Value: User{}},
+		{
+			Name:  "nested",
+			Value: User{Address: &Address{City: "Paris", Geo: &Geo{Lat: 48.8}}},
+		},
+		{
+			Name:  "nested invalid",
+			Value: User{Address: &Address{Geo: &Geo{Lat: 91}}},
+			Want: []validtest.Violation{
+				{Path: "Address.City", Code: "notEmpty"},
+				{Path: "Address.Geo.Lat", Code: "lte"},
+			},
+		},
+		{
+			Name:  "promoted",
+			Value: User{Timestamps: &Timestamps{CreatedAt: -1}},
+			Want:  []validtest.Violation{{Path: "CreatedAt", Code: "positive"}},
+		},
+	}.Run(t, v)
+
+	type hidden struct {
+		Secret string
+	}
+
+	type Wrapper struct {
+		hidden
+		Name string
+	}
+
+	_, err := validators.NewStructValidator[Wrapper](validators.StructShape{
+		"Secret":    validators.NewStringValidator[string](),
+		"Name.Len":  validators.NewNumberValidator[int](),
+		"Name":      validators.NewStringValidator[string](),
+		"Name.Nope": validators.NewStringValidator[string](),
+	})
+	if err == nil {
+		t.Fatal("expected invalid paths to fail")
+	}
+
+	for _, want := range []string{
+		"Wrapper.Secret: validators_test.Wrapper.Secret is promoted through unexported field hidden",
+		"Wrapper.Name.Len: string is not a struct",
+	} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("expected error to contain %q, got:\n%v", want, err)
+		}
+	}
+}
+
+func TestStructValidatorExhaustive(t *testing.T) {
+	type Audit struct {
+		CreatedBy string
+		UpdatedBy string
+	}
+
+	type internal struct {
+		Hidden string
+	}
+
+	type Address struct {
+		City string
+		Zip  string
+	}
+
+	type User struct {
+		Audit
+		internal
+		Name    string
+		Email   string
+		Address *Address
+		secret  string
+	}
+
+	str := validators.NewStringValidator[string]()
+
+	_, err := validators.NewStructValidator[User](validators.StructShape{
+		"Name":      str,
+		"CreatedBy": str,
+	}, validators.Exhaustive())
+	if err == nil {
+		t.Fatal("expected missing fields to fail")
+	}
+
+	for _, want := range []string{"User.Address: no validator", "User.Email: no validator", "User.UpdatedBy: no validator"} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("expected error to contain %q, got:\n%v", want, err)
+		}
+	}
+
+	for _, unwanted := range []string{"Hidden", "secret", "CreatedBy", "Name:"} {
+		if strings.Contains(err.Error(), unwanted) {
+			t.Errorf("expected error not to mention %q, got:\n%v", unwanted, err)
+		}
+	}
+
+	v, err := validators.NewStructValidator[User](validators.StructShape{
+		"Name":         str,
+		"Audit":        validators.MustStructValidator[Audit](nil),
+		"Address.City": str.NotEmpty(),
+	}, validators.Exhaustive(), validators.Ignore("Email"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	validtest.AssertInvalid(t, v, User{Address: &Address{}}, "Address.City", "notEmpty")
+
+	if _, err := validators.NewStructValidator[User](nil, validators.Ignore("Emial")); err == nil || !strings.Contains(err.Error(), "cannot ignore") {
+		t.Errorf("expected ignoring unknown field to fail, got %v", err)
+	}
+}
+
+func TestStructValidatorInterfaceNil(t *testing.T) {
+	type Foo struct {
+		Bar string
+	}
+
+	v := validators.MustStructValidator[any](validators.StructShape{
+		"Bar": validators.NewStringValidator[string]().NotEmpty(),
+	})
+
+	validtest.AssertValid(t, v, any(Foo{Bar: "a"}))
+	validtest.AssertInvalid(t, v, nil, "", validators.CodeType)
+	validtest.AssertInvalid(t, validators.MustStructValidator[any](nil).NotZero(), nil, "", "notZero")
+	validtest.AssertValid(t, validators.MustStructValidator[any](nil).Zero(), nil)
+}
+
+func TestStructValidatorForType(t *testing.T) {
+	profile := validators.ForType(reflect.TypeFor[Profile]())
+
+	v := validators.MustStructValidator[any](validators.StructShape{
+		"Name": validators.NewStringValidator[string]().NotEmpty(),
+	}, profile)
+
+	validtest.AssertValid(t, v, any(Profile{Name: "a"}))
+	validtest.AssertInvalid(t, v, any(Profile{}), "Name", "notEmpty")
+	validtest.AssertInvalid(t, v, any(Login{}), "", validators.CodeType)
+	validtest.AssertInvalid(t, v, nil, "", validators.CodeType)
+
+	if _, err := validators.NewStructValidator[any](validators.StructShape{"Missing": v}, profile); err == nil {
+		t.Errorf("expected the shape to be checked against the type")
+	}
+
+	if _, err := validators.NewStructValidator[Profile](nil, profile); err == nil {
+		t.Errorf("expected ForType to require an interface type")
+	}
+}
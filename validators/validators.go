@@ -15,8 +15,19 @@ type (
 		Validate(value T) error
 	}
 
+	// RuleValidator is implemented by the built-in validators, whose rules can
+	// be inspected after they are built.
+	RuleValidator interface {
+		Describer
+
+		Rules() []Rule
+		// String renders the validator as text, see Description.String.
+		String() string
+	}
+
 	StringValidator[T ~string] interface {
 		Validator[T]
+		RuleValidator
 
 		Empty() StringValidator[T]
 		NotEmpty() StringValidator[T]
@@ -41,10 +52,22 @@ type (
 		ValidUUID() StringValidator[T]
 
 		Satisfies(check func(T) error) StringValidator[T]
+
+		Groups(groups ...string) StringValidator[T]
+		AsWarning() StringValidator[T]
+		Sensitive() StringValidator[T]
+		MaxErrors(n int) StringValidator[T]
+		ValidateGroups(value T, groups ...string) error
+		Check(value T, groups ...string) Result
+		Explain(value T, groups ...string) *Explanation
+
+		Seal() StringValidator[T]
+		Sealed() bool
 	}
 
 	NumberValidator[T constraints.Integer | constraints.Float] interface {
 		Validator[T]
+		RuleValidator
 
 		Positive() NumberValidator[T]
 		Negative() NumberValidator[T]
@@ -60,10 +83,22 @@ type (
 		NotIn(haystack ...T) NumberValidator[T]
 
 		Satisfies(check func(T) error) NumberValidator[T]
+
+		Groups(groups ...string) NumberValidator[T]
+		AsWarning() NumberValidator[T]
+		Sensitive() NumberValidator[T]
+		MaxErrors(n int) NumberValidator[T]
+		ValidateGroups(value T, groups ...string) error
+		Check(value T, groups ...string) Result
+		Explain(value T, groups ...string) *Explanation
+
+		Seal() NumberValidator[T]
+		Sealed() bool
 	}
 
 	MapValidator[K comparable, V any] interface {
 		Validator[map[K]V]
+		RuleValidator
 
 		Empty() MapValidator[K, V]
 		NotEmpty() MapValidator[K, V]
@@ -73,10 +108,22 @@ type (
 		NotHasKeyIn(haystack ...K) MapValidator[K, V]
 
 		Satisfies(check func(map[K]V) error) MapValidator[K, V]
+
+		Groups(groups ...string) MapValidator[K, V]
+		AsWarning() MapValidator[K, V]
+		Sensitive() MapValidator[K, V]
+		MaxErrors(n int) MapValidator[K, V]
+		ValidateGroups(value map[K]V, groups ...string) error
+		Check(value map[K]V, groups ...string) Result
+		Explain(value map[K]V, groups ...string) *Explanation
+
+		Seal() MapValidator[K, V]
+		Sealed() bool
 	}
 
 	SliceValidator[S ~[]E, E any, V Validator[E]] interface {
 		Validator[S]
+		RuleValidator
 
 		ElemValidator() V
 
@@ -90,10 +137,22 @@ type (
 		NoneSatisfy(v V) SliceValidator[S, E, V]
 
 		Satisfies(check func(S) error) SliceValidator[S, E, V]
+
+		Groups(groups ...string) SliceValidator[S, E, V]
+		AsWarning() SliceValidator[S, E, V]
+		Sensitive() SliceValidator[S, E, V]
+		MaxErrors(n int) SliceValidator[S, E, V]
+		ValidateGroups(value S, groups ...string) error
+		Check(value S, groups ...string) Result
+		Explain(value S, groups ...string) *Explanation
+
+		Seal() SliceValidator[S, E, V]
+		Sealed() bool
 	}
 
 	PointerValidator[T any, V Validator[T]] interface {
 		Validator[*T]
+		RuleValidator
 
 		ElemValidator() V
 
@@ -101,5 +160,16 @@ type (
 		NotNil() PointerValidator[T, V]
 
 		Satisfies(check func(*T) error) PointerValidator[T, V]
+
+		Groups(groups ...string) PointerValidator[T, V]
+		AsWarning() PointerValidator[T, V]
+		Sensitive() PointerValidator[T, V]
+		MaxErrors(n int) PointerValidator[T, V]
+		ValidateGroups(value *T, groups ...string) error
+		Check(value *T, groups ...string) Result
+		Explain(value *T, groups ...string) *Explanation
+
+		Seal() PointerValidator[T, V]
+		Sealed() bool
 	}
 )
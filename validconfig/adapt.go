@@ -0,0 +1,169 @@
+package validconfig
+
+import (
+	"fmt"
+	"reflect"
+
+	"github.com/bitcrshr/valid/validators"
+)
+
+// converter validates values of type t with a validator built for their
+// basic representation, and runs the custom rules registered for t.
+type converter struct {
+	t       reflect.Type
+	inner   validators.AnyValidator
+	convert func(reflect.Value) any
+	custom  []customRule
+}
+
+type customRule struct {
+	rule  validators.Rule
+	check reflect.Value
+}
+
+func (c *converter) ValidateAny(value any) error {
+	return c.ValidateAnyIn(validators.NewEvaluation(), value)
+}
+
+func (c *converter) ValidateAnyGroups(value any, groups ...string) error {
+	return c.ValidateAnyIn(validators.NewEvaluation(groups...), value)
+}
+
+// ValidateAnyIn passes ev on to the built-in validators, so that it reaches
+// validators nested in them, and applies it to custom rules. The built-in
+// validator shows value, rather than its conversion, in its errors.
+func (c *converter) ValidateAnyIn(ev *validators.Evaluation, value any) error {
+	rv := reflect.ValueOf(value)
+	if !rv.IsValid() || rv.Type() != c.t {
+		return &validators.Error{
+			Code:    validators.CodeType,
+			Message: fmt.Sprintf("expected value of type %s, but found %T", c.t, value),
+		}
+	}
+
+	ev = ev.Converted(value)
+	if err := c.inner.(validators.EvaluationValidator).ValidateAnyIn(ev, c.convert(rv)); err != nil {
+		return err
+	}
+
+	for _, r := range c.custom {
+		if !ev.Checks(r.rule) {
+			continue
+		}
+
+		if err, _ := r.check.Call([]reflect.Value{rv})[0].Interface().(error); err != nil {
+			if err := ev.Report(r.rule, &validators.Error{Code: r.rule.Name, Message: err.Error(), Err: err}); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// field returns the validator of c for a struct field: the built-in validator
+// itself if it validates values of type t as they are and there are no custom
+// rules, so that the struct validator can validate the field as a scalar.
+func (c *converter) field() validators.AnyValidator {
+	m, ok := reflect.TypeOf(c.inner).MethodByName("Validate")
+	if len(c.custom) > 0 || !ok || m.Type.NumIn() != 2 || m.Type.In(1) != c.t {
+		return c
+	}
+
+	return c.inner
+}
+
+func (c *converter) Rules() []validators.Rule {
+	d := c.Describe()
+	return d.Rules
+}
+
+func (c *converter) Describe() validators.Description {
+	d := validators.Describe(c.inner)
+	d.Type = c.t.String()
+	for _, r := range c.custom {
+		d.Rules = append(d.Rules, r.rule)
+	}
+
+	return d
+}
+
+func (c *converter) String() string {
+	return c.Describe().String()
+}
+
+// anyValidator adapts the converter of an element type to the element
+// validator of slice and pointer validators, which validate values of type
+// any. A nil converter accepts every element.
+type anyValidator struct {
+	*converter
+}
+
+func (v anyValidator) Validate(value any) error {
+	return v.ValidateAny(value)
+}
+
+func (v anyValidator) ValidateAny(value any) error {
+	if v.converter == nil {
+		return nil
+	}
+
+	return v.converter.ValidateAny(value)
+}
+
+func (v anyValidator) ValidateAnyGroups(value any, groups ...string) error {
+	if v.converter == nil {
+		return nil
+	}
+
+	return v.converter.ValidateAnyGroups(value, groups...)
+}
+
+func (v anyValidator) ValidateAnyIn(ev *validators.Evaluation, value any) error {
+	if v.converter == nil {
+		return nil
+	}
+
+	return v.converter.ValidateAnyIn(ev, value)
+}
+
+func (v anyValidator) Rules() []validators.Rule {
+	if v.converter == nil {
+		return nil
+	}
+
+	return v.converter.Rules()
+}
+
+func (v anyValidator) Describe() validators.Description {
+	if v.converter == nil {
+		return validators.Description{}
+	}
+
+	return v.converter.Describe()
+}
+
+func (v anyValidator) String() string {
+	return v.Describe().String()
+}
+
+// typed is the validator returned by Load.
+type typed[T any] struct {
+	*converter
+}
+
+func (v *typed[T]) Validate(value T) error {
+	return v.ValidateAny(value)
+}
+
+func (v *typed[T]) ValidateGroups(value T, groups ...string) error {
+	return v.ValidateAnyGroups(value, groups...)
+}
+
+func (v *typed[T]) Check(value T, groups ...string) validators.Result {
+	return validators.Check(v, value, groups...)
+}
+
+func (v *typed[T]) Explain(value T, groups ...string) *validators.Explanation {
+	return validators.Explain(v, value, groups...)
+}
@@ -0,0 +1,156 @@
+// Package validconfig loads validators from declarative JSON definitions and
+// serializes built-in validators back into that format, so that limits such
+// as maximum lengths or allowed values can be changed without a redeploy.
+//
+// A definition mirrors validators.Description:
+//
+//	{
+//	  "kind": "struct",
+//	  "fields": {
+//	    "Name": {"kind": "string", "rules": [{"name": "minLen", "params": [2]}]},
+//	    "Tags": {
+//	      "kind": "slice",
+//	      "rules": [{"name": "maxLen", "params": [10]}],
+//	      "elem": {"kind": "string", "rules": [{"name": "notEmpty"}]}
+//	    },
+//	    "Age": {"kind": "number", "rules": [{"name": "gte", "params": [21]}, {"name": "even"}]}
+//	  }
+//	}
+//
+// Rule names are those reported by validators.Rule, e.g. "minLen" or "uuid",
+// and params are given in the order of the corresponding builder method. Rules
+// that are not built in, like "even" above, refer to checks registered with
+// Register.
+package validconfig
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+
+	"github.com/bitcrshr/valid/validators"
+)
+
+// Definition is the JSON representation of a validator.
+type Definition struct {
+	// Kind is one of "string", "number", "slice", "map", "pointer" or
+	// "struct". It may be omitted, in which case it is inferred from the Go
+	// type the definition is loaded for.
+	Kind   string                `json:"kind,omitempty"`
+	Rules  []RuleDefinition      `json:"rules,omitempty"`
+	Elem   *Definition           `json:"elem,omitempty"`
+	Fields map[string]Definition `json:"fields,omitempty"`
+	// Sensitive redacts the validated values in errors, see
+	// validators.Description.
+	Sensitive bool `json:"sensitive,omitempty"`
+	// MaxErrors limits the number of violations reported, see
+	// validators.Description.
+	MaxErrors int `json:"maxErrors,omitempty"`
+}
+
+// RuleDefinition is the JSON representation of a rule. Params are decoded
+// into the parameter types of the builder method the rule refers to, so e.g.
+// the params of "in" on a number validator must be numbers.
+type RuleDefinition struct {
+	Name   string            `json:"name"`
+	Params []json.RawMessage `json:"params,omitempty"`
+	// Groups are the validation groups of the rule, see validators.Rule.
+	Groups []string `json:"groups,omitempty"`
+	// Warning marks the rule as a warning, see validators.Rule.
+	Warning bool `json:"warning,omitempty"`
+}
+
+// DefinitionOf converts the description of v into a definition. It fails for
+// validators whose rules cannot be represented as data, such as custom
+// validators and Satisfies checks.
+func DefinitionOf(v validators.AnyValidator) (Definition, error) {
+	return definitionOf(validators.Describe(v), "")
+}
+
+// Marshal returns the JSON definition of v.
+func Marshal(v validators.AnyValidator) ([]byte, error) {
+	def, err := DefinitionOf(v)
+	if err != nil {
+		return nil, err
+	}
+
+	return json.Marshal(def)
+}
+
+func definitionOf(d validators.Description, path string) (Definition, error) {
+	if d.Kind == "custom" {
+		return Definition{}, fmt.Errorf("validconfig: %s: custom validator for %s cannot be serialized", displayPath(path), d.Type)
+	}
+
+	def := Definition{Kind: d.Kind, Sensitive: d.Sensitive, MaxErrors: d.MaxErrors}
+
+	for _, r := range d.Rules {
+		if r.Name == "satisfies" {
+			return Definition{}, fmt.Errorf("validconfig: %s: satisfies rules cannot be serialized", displayPath(path))
+		}
+
+		rd := RuleDefinition{Name: r.Name, Groups: r.Groups, Warning: r.Warning}
+		for _, p := range r.Params {
+			switch pv := p.(type) {
+			case validators.Description:
+				nested, err := definitionOf(pv, path)
+				if err != nil {
+					return Definition{}, err
+				}
+
+				p = nested
+			case *regexp.Regexp:
+				p = pv.String()
+			}
+
+			raw, err := json.Marshal(p)
+			if err != nil {
+				return Definition{}, fmt.Errorf("validconfig: %s: param of %s: %w", displayPath(path), r.Name, err)
+			}
+
+			rd.Params = append(rd.Params, raw)
+		}
+
+		def.Rules = append(def.Rules, rd)
+	}
+
+	if d.Elem != nil {
+		elem, err := definitionOf(*d.Elem, path+"[]")
+		if err != nil {
+			return Definition{}, err
+		}
+
+		def.Elem = &elem
+	}
+
+	for name, fd := range d.Fields {
+		field, err := definitionOf(fd, joinPath(path, name))
+		if err != nil {
+			return Definition{}, err
+		}
+
+		if def.Fields == nil {
+			def.Fields = make(map[string]Definition, len(d.Fields))
+		}
+
+		def.Fields[name] = field
+	}
+
+	return def, nil
+}
+
+func joinPath(path, name string) string {
+	if path == "" {
+		return name
+	}
+
+	return path + "." + name
+}
+
+func displayPath(path string) string {
+	if path == "" {
+		return "<root>"
+	}
+
+	return path
+}
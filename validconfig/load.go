@@ -0,0 +1,476 @@
+package validconfig
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"regexp"
+	"strings"
+
+	"github.com/bitcrshr/valid/validators"
+)
+
+// Registry holds the custom rules definitions may refer to by name.
+type Registry struct {
+	rules map[string]reflect.Value
+}
+
+func NewRegistry() *Registry {
+	return &Registry{rules: map[string]reflect.Value{}}
+}
+
+// Register makes check available to definitions as a rule called name. The
+// rule may be used on any value whose type is T. Built-in rules take
+// precedence over registered ones of the same name.
+func Register[T any](r *Registry, name string, check func(T) error) {
+	r.rules[name] = reflect.ValueOf(check)
+}
+
+// Load parses a JSON definition and builds a validator for T from it.
+// Mismatches between the definition and T, such as unknown fields, kinds that
+// do not match the Go type or rules with invalid params, are reported
+// together in the returned error. The registry may be nil if the definition
+// uses only built-in rules.
+func Load[T any](data []byte, r *Registry) (validators.Validator[T], error) {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.DisallowUnknownFields()
+
+	var def Definition
+	if err := dec.Decode(&def); err != nil {
+		return nil, fmt.Errorf("validconfig: %w", err)
+	}
+
+	return Compile[T](def, r)
+}
+
+// Compile builds a validator for T from def. See Load.
+func Compile[T any](def Definition, r *Registry) (_ validators.Validator[T], err error) {
+	if r == nil {
+		r = NewRegistry()
+	}
+
+	// Definitions are checked before builder methods are called, so this
+	// only guards against builders that reject their params by panicking.
+	defer func() {
+		if p := recover(); p != nil {
+			err = fmt.Errorf("validconfig: %v", p)
+		}
+	}()
+
+	c := &compiler{registry: r}
+
+	v := c.compile(def, reflect.TypeFor[T](), "")
+	if len(c.errs) > 0 {
+		return nil, errors.Join(c.errs...)
+	}
+
+	return &typed[T]{v}, nil
+}
+
+type compiler struct {
+	registry *Registry
+	errs     []error
+}
+
+func (c *compiler) errorf(path string, format string, args ...any) {
+	c.errs = append(c.errs, fmt.Errorf("validconfig: %s: %s", displayPath(path), fmt.Sprintf(format, args...)))
+}
+
+// compile builds a validator for values of type t. Since generic validators
+// cannot be instantiated at runtime, it builds one for the basic
+// representation of t, e.g. string for ~string types or []any for slices, and
+// wraps it in a converter. Struct validators validate values of type any,
+// with their fields resolved against t, see validators.ForType.
+func (c *compiler) compile(def Definition, t reflect.Type, path string) *converter {
+	kind := kindOf(t)
+	if kind == "" {
+		c.errorf(path, "values of type %s cannot be validated", t)
+		return nil
+	}
+
+	if def.Kind != "" && def.Kind != kind {
+		c.errorf(path, "definition of kind %s cannot validate %s of kind %s", def.Kind, t, kind)
+		return nil
+	}
+
+	if def.Elem != nil && kind != "slice" && kind != "pointer" {
+		c.errorf(path, "%s validators have no elem", kind)
+	}
+
+	if def.Fields != nil && kind != "struct" {
+		c.errorf(path, "%s validators have no fields", kind)
+	}
+
+	conv := &converter{t: t}
+
+	var v reflect.Value
+	switch kind {
+	case "string":
+		v = reflect.ValueOf(validators.NewStringValidator[string]())
+		conv.convert = func(rv reflect.Value) any { return rv.String() }
+	case "number":
+		v = reflect.ValueOf(numberValidators[t.Kind()]())
+		basic := basicNumbers[t.Kind()]
+		conv.convert = func(rv reflect.Value) any { return rv.Convert(basic).Interface() }
+	case "slice":
+		elem := c.compileElem(def.Elem, t.Elem(), path+"[]")
+		v = reflect.ValueOf(validators.NewSliceValidator[[]any](validators.Validator[any](elem)))
+		conv.convert = func(rv reflect.Value) any {
+			if rv.IsNil() {
+				return []any(nil)
+			}
+
+			s := make([]any, rv.Len())
+			for i := range s {
+				s[i] = rv.Index(i).Interface()
+			}
+
+			return s
+		}
+	case "pointer":
+		elem := c.compileElem(def.Elem, t.Elem(), path)
+		v = reflect.ValueOf(validators.NewPointerValidator[any](validators.Validator[any](elem)))
+		conv.convert = func(rv reflect.Value) any {
+			if rv.IsNil() {
+				return (*any)(nil)
+			}
+
+			p := new(any)
+			*p = rv.Elem().Interface()
+
+			return p
+		}
+	case "map":
+		v = reflect.ValueOf(validators.NewMapValidator[any, any]())
+		conv.convert = func(rv reflect.Value) any {
+			if rv.IsNil() {
+				return map[any]any(nil)
+			}
+
+			m := make(map[any]any, rv.Len())
+			for iter := rv.MapRange(); iter.Next(); {
+				m[iter.Key().Interface()] = iter.Value().Interface()
+			}
+
+			return m
+		}
+	case "struct":
+		v = reflect.ValueOf(validators.MustStructValidator[any](c.compileShape(def.Fields, t, path), validators.ForType(t)))
+		conv.convert = reflect.Value.Interface
+	}
+
+	if def.Sensitive {
+		v = v.MethodByName("Sensitive").Call(nil)[0]
+	}
+
+	if def.MaxErrors > 0 {
+		v = v.MethodByName("MaxErrors").Call([]reflect.Value{reflect.ValueOf(def.MaxErrors)})[0]
+	}
+
+	for _, r := range def.Rules {
+		if name, ok := builtinRules[kind][r.Name]; ok {
+			if out, ok := c.applyRule(v.MethodByName(name), r, t, path); ok {
+				v = out
+				if len(r.Groups) > 0 {
+					v = v.MethodByName("Groups").Call(groupArgs(r.Groups))[0]
+				}
+				if r.Warning {
+					v = v.MethodByName("AsWarning").Call(nil)[0]
+				}
+			}
+
+			continue
+		}
+
+		check, ok := c.registry.rules[r.Name]
+		switch {
+		case !ok:
+			c.errorf(path, "unknown %s rule %s", kind, r.Name)
+		case check.Type().In(0) != t:
+			c.errorf(path, "rule %s validates %s, not %s", r.Name, check.Type().In(0), t)
+		case len(r.Params) > 0:
+			c.errorf(path, "custom rule %s takes no params", r.Name)
+		default:
+			conv.custom = append(conv.custom, customRule{rule: validators.Rule{Name: r.Name, Groups: r.Groups, Warning: r.Warning}, check: check})
+		}
+	}
+
+	inner, ok := v.Interface().(validators.AnyValidator)
+	if !ok {
+		c.errorf(path, "%s is not a validator", v.Type())
+		return nil
+	}
+
+	conv.inner = inner
+
+	return conv
+}
+
+func (c *compiler) compileElem(def *Definition, t reflect.Type, path string) validators.Validator[any] {
+	if def == nil {
+		return anyValidator{}
+	}
+
+	return anyValidator{c.compile(*def, t, path)}
+}
+
+func (c *compiler) compileShape(fields map[string]Definition, t reflect.Type, path string) validators.StructShape {
+	shape := make(validators.StructShape, len(fields))
+	for name, def := range fields {
+		ft, err := fieldType(t, name)
+		if err != nil {
+			c.errorf(joinPath(path, name), "%v", err)
+			continue
+		}
+
+		if fv := c.compile(def, ft, joinPath(path, name)); fv != nil {
+			shape[name] = fv.field()
+		}
+	}
+
+	return shape
+}
+
+// fieldType returns the type of the field name refers to in t, which may be
+// promoted from an embedded struct or be a dotted path into nested structs,
+// like the keys of validators.StructShape.
+func fieldType(t reflect.Type, name string) (reflect.Type, error) {
+	for seg := range strings.SplitSeq(name, ".") {
+		for t.Kind() == reflect.Pointer {
+			t = t.Elem()
+		}
+
+		if t.Kind() != reflect.Struct {
+			return nil, fmt.Errorf("%s is not a struct", t)
+		}
+
+		f, ok := t.FieldByName(seg)
+		if !ok || !f.IsExported() {
+			return nil, fmt.Errorf("%s has no exported field %s", t, seg)
+		}
+
+		t = f.Type
+	}
+
+	return t, nil
+}
+
+func groupArgs(groups []string) []reflect.Value {
+	args := make([]reflect.Value, len(groups))
+	for i, g := range groups {
+		args[i] = reflect.ValueOf(g)
+	}
+
+	return args
+}
+
+// applyRule calls the builder method m with the params of r decoded into the
+// method's parameter types and returns the resulting validator.
+func (c *compiler) applyRule(m reflect.Value, r RuleDefinition, t reflect.Type, path string) (reflect.Value, bool) {
+	if !m.IsValid() {
+		c.errorf(path, "rule %s is not supported for %s", r.Name, t)
+		return reflect.Value{}, false
+	}
+
+	mt := m.Type()
+	if mt.NumOut() != 1 || !mt.Out(0).Implements(anyValidatorType) {
+		c.errorf(path, "rule %s does not build a validator", r.Name)
+		return reflect.Value{}, false
+	}
+
+	if n := mt.NumIn(); len(r.Params) < n-1 || (!mt.IsVariadic() && len(r.Params) != n) {
+		c.errorf(path, "rule %s takes %d params, but got %d", r.Name, n, len(r.Params))
+		return reflect.Value{}, false
+	}
+
+	args := make([]reflect.Value, len(r.Params))
+	for i, raw := range r.Params {
+		pt := mt.In(min(i, mt.NumIn()-1))
+		if mt.IsVariadic() && i >= mt.NumIn()-1 {
+			pt = pt.Elem()
+		}
+
+		arg, err := c.decodeParam(raw, pt, t, path)
+		if err != nil {
+			c.errorf(path, "param %d of rule %s: %v", i, r.Name, err)
+			return reflect.Value{}, false
+		}
+
+		args[i] = arg
+	}
+
+	return m.Call(args)[0], true
+}
+
+var (
+	regexpType       = reflect.TypeFor[*regexp.Regexp]()
+	validatorType    = reflect.TypeFor[validators.Validator[any]]()
+	anyType          = reflect.TypeFor[any]()
+	anyValidatorType = reflect.TypeFor[validators.AnyValidator]()
+)
+
+// decodeParam decodes raw into a value of the parameter type pt of a builder
+// method of a validator for t.
+func (c *compiler) decodeParam(raw json.RawMessage, pt, t reflect.Type, path string) (reflect.Value, error) {
+	switch pt {
+	case regexpType:
+		var expr string
+		if err := json.Unmarshal(raw, &expr); err != nil {
+			return reflect.Value{}, err
+		}
+
+		re, err := regexp.Compile(expr)
+		if err != nil {
+			return reflect.Value{}, err
+		}
+
+		return reflect.ValueOf(re), nil
+	case validatorType:
+		var def Definition
+		if err := json.Unmarshal(raw, &def); err != nil {
+			return reflect.Value{}, err
+		}
+
+		return reflect.ValueOf(c.compileElem(&def, t.Elem(), path+"[]")), nil
+	case anyType:
+		// The only rules with params of type any are those of map validators,
+		// whose params are keys.
+		key := reflect.New(t.Key())
+		if err := json.Unmarshal(raw, key.Interface()); err != nil {
+			return reflect.Value{}, err
+		}
+
+		return key.Elem().Convert(anyType), nil
+	}
+
+	p := reflect.New(pt)
+	if err := json.Unmarshal(raw, p.Interface()); err != nil {
+		return reflect.Value{}, err
+	}
+
+	return p.Elem(), nil
+}
+
+// builtinRules lists the rules definitions may use for each kind of
+// validator, with the names of the builder methods that add them. Other
+// methods of the validators, such as Seal or Groups, are not rules.
+var builtinRules = map[string]map[string]string{
+	"string": {
+		"empty":           "Empty",
+		"notEmpty":        "NotEmpty",
+		"len":             "Len",
+		"minLen":          "MinLen",
+		"maxLen":          "MaxLen",
+		"equalTo":         "EqualTo",
+		"notEqualTo":      "NotEqualTo",
+		"hasPrefix":       "HasPrefix",
+		"notHasPrefix":    "NotHasPrefix",
+		"hasSuffix":       "HasSuffix",
+		"notHasSuffix":    "NotHasSuffix",
+		"contains":        "Contains",
+		"notContains":     "NotContains",
+		"containsAtLeast": "ContainsAtLeast",
+		"containsAtMost":  "ContainsAtMost",
+		"containsExact":   "ContainsExact",
+		"in":              "In",
+		"notIn":           "NotIn",
+		"matches":         "Matches",
+		"notMatches":      "NotMatches",
+		"uuid":            "ValidUUID",
+	},
+	"number": {
+		"positive":   "Positive",
+		"negative":   "Negative",
+		"zero":       "Zero",
+		"nonZero":    "NonZero",
+		"lt":         "LT",
+		"lte":        "LTE",
+		"gt":         "GT",
+		"gte":        "GTE",
+		"equalTo":    "EqualTo",
+		"notEqualTo": "NotEqualTo",
+		"in":         "In",
+		"notIn":      "NotIn",
+	},
+	"slice": {
+		"empty":       "Empty",
+		"notEmpty":    "NotEmpty",
+		"len":         "Len",
+		"minLen":      "MinLen",
+		"maxLen":      "MaxLen",
+		"allSatisfy":  "AllSatisfy",
+		"anySatisfy":  "AnySatisfy",
+		"noneSatisfy": "NoneSatisfy",
+	},
+	"pointer": {
+		"nil":    "Nil",
+		"notNil": "NotNil",
+	},
+	"map": {
+		"empty":       "Empty",
+		"notEmpty":    "NotEmpty",
+		"hasKey":      "HasKey",
+		"notHasKey":   "NotHasKey",
+		"hasKeyIn":    "HasKeyIn",
+		"notHasKeyIn": "NotHasKeyIn",
+	},
+	"struct": {
+		"zero":    "Zero",
+		"notZero": "NotZero",
+	},
+}
+
+func kindOf(t reflect.Type) string {
+	switch t.Kind() {
+	case reflect.String:
+		return "string"
+	case reflect.Slice:
+		return "slice"
+	case reflect.Pointer:
+		return "pointer"
+	case reflect.Map:
+		return "map"
+	case reflect.Struct:
+		return "struct"
+	}
+
+	if _, ok := basicNumbers[t.Kind()]; ok {
+		return "number"
+	}
+
+	return ""
+}
+
+var basicNumbers = map[reflect.Kind]reflect.Type{
+	reflect.Int:     reflect.TypeFor[int](),
+	reflect.Int8:    reflect.TypeFor[int8](),
+	reflect.Int16:   reflect.TypeFor[int16](),
+	reflect.Int32:   reflect.TypeFor[int32](),
+	reflect.Int64:   reflect.TypeFor[int64](),
+	reflect.Uint:    reflect.TypeFor[uint](),
+	reflect.Uint8:   reflect.TypeFor[uint8](),
+	reflect.Uint16:  reflect.TypeFor[uint16](),
+	reflect.Uint32:  reflect.TypeFor[uint32](),
+	reflect.Uint64:  reflect.TypeFor[uint64](),
+	reflect.Uintptr: reflect.TypeFor[uintptr](),
+	reflect.Float32: reflect.TypeFor[float32](),
+	reflect.Float64: reflect.TypeFor[float64](),
+}
+
+var numberValidators = map[reflect.Kind]func() any{
+	reflect.Int:     func() any { return validators.NewNumberValidator[int]() },
+	reflect.Int8:    func() any { return validators.NewNumberValidator[int8]() },
+	reflect.Int16:   func() any { return validators.NewNumberValidator[int16]() },
+	reflect.Int32:   func() any { return validators.NewNumberValidator[int32]() },
+	reflect.Int64:   func() any { return validators.NewNumberValidator[int64]() },
+	reflect.Uint:    func() any { return validators.NewNumberValidator[uint]() },
+	reflect.Uint8:   func() any { return validators.NewNumberValidator[uint8]() },
+	reflect.Uint16:  func() any { return validators.NewNumberValidator[uint16]() },
+	reflect.Uint32:  func() any { return validators.NewNumberValidator[uint32]() },
+	reflect.Uint64:  func() any { return validators.NewNumberValidator[uint64]() },
+	reflect.Uintptr: func() any { return validators.NewNumberValidator[uintptr]() },
+	reflect.Float32: func() any { return validators.NewNumberValidator[float32]() },
+	reflect.Float64: func() any { return validators.NewNumberValidator[float64]() },
+}
@@ -0,0 +1,362 @@
+package validconfig_test
+
+import (
+	"errors"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/bitcrshr/valid/validators"
+	"github.com/bitcrshr/valid/validconfig"
+	"github.com/bitcrshr/valid/validtest"
+)
+
+type Email string
+
+type Address struct {
+	City string
+}
+
+type User struct {
+	Name    string
+	Email   Email
+	Age     int8
+	Score   float64
+	Tags    []string
+	Address *Address
+	Labels  map[string]int
+}
+
+const userDefinition = `{
+	"kind": "struct",
+	"fields": {
+		"Name": {"kind": "string", "rules": [{"name": "minLen", "params": [2]}, {"name": "maxLen", "params": [10]}]},
+		"Email": {"kind": "string", "rules": [{"name": "matches", "params": ["^[^@]+@[^@]+$"]}]},
+		"Age": {"kind": "number", "rules": [{"name": "gte", "params": [21]}, {"name": "even"}]},
+		"Score": {"rules": [{"name": "in", "params": [0.5, 1.5]}]},
+		"Tags": {
+			"kind": "slice",
+			"rules": [{"name": "maxLen", "params": [2]}, {"name": "noneSatisfy", "params": [{"rules": [{"name": "equalTo", "params": ["banned"]}]}]}],
+			"elem": {"kind": "string", "rules": [{"name": "notEmpty"}]}
+		},
+		"Address": {
+			"kind": "pointer",
+			"rules": [{"name": "notNil"}],
+			"elem": {"kind": "struct", "fields": {"City": {"kind": "string", "rules": [{"name": "in", "params": ["Paris", "Rome"]}]}}}
+		},
+		"Labels": {"kind": "map", "rules": [{"name": "notHasKey", "params": ["internal"]}]}
+	}
+}`
+
+func registry() *validconfig.Registry {
+	r := validconfig.NewRegistry()
+	validconfig.Register(r, "even", func(n int8) error {
+		if n%2 != 0 {
+			return errors.New("expected an even number")
+		}
+
+		return nil
+	})
+
+	return r
+}
+
+func TestLoad(t *testing.T) {
+	v, err := validconfig.Load[User]([]byte(userDefinition), registry())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	valid := User{
+		Name:    "Ada",
+		Email:   "ada@example.com",
+		Age:     36,
+		Score:   1.5,
+		Tags:    []string{"math"},
+		Address: &Address{City: "Paris"},
+		Labels:  map[string]int{"team": 1},
+	}
+
+	validtest.Cases[User]{
+		{Name: "valid", Value: valid},
+		{
+			Name: "invalid",
+			Value: User{
+				Name:    "A",
+				Email:   "nope",
+				Age:     21,
+				Score:   1,
+				Tags:    []string{"", "banned", "x"},
+				Address: &Address{City: "Oslo"},
+				Labels:  map[string]int{"internal": 1},
+			},
+			Want: []validtest.Violation{
+				{Path: "Address.City", Code: "in"},
+				{Path: "Age", Code: "even"},
+				{Path: "Email", Code: "matches"},
+				{Path: "Labels", Code: "notHasKey"},
+				{Path: "Name", Code: "minLen"},
+				{Path: "Score", Code: "in"},
+				{Path: "Tags[0]", Code: "notEmpty"},
+			},
+		},
+		{
+			Name:  "nil address",
+			Value: User{Name: "Ada", Email: "a@b", Age: 22, Score: 0.5},
+			Want:  []validtest.Violation{{Path: "Address", Code: "notNil"}},
+		},
+	}.Run(t, v)
+
+	if err := v.ValidateAny("nope"); err == nil {
+		t.Errorf("expected value of wrong type to fail")
+	}
+}
+
+func TestLoadErrors(t *testing.T) {
+	tests := []struct {
+		def  string
+		want []string
+	}{
+		{
+			def: `{"kind": "struct", "fields": {"Nmae": {"kind": "string"}, "Age": {"kind": "string"}}}`,
+			want: []string{
+				"Nmae: validconfig_test.User has no exported field Nmae",
+				"Age: definition of kind string cannot validate int8 of kind number",
+			},
+		},
+		{
+			def:  `{"kind": "struct", "fields": {"Age": {"rules": [{"name": "gte", "params": [1000]}]}}}`,
+			want: []string{"Age: param 0 of rule gte"},
+		},
+		{
+			def:  `{"kind": "struct", "fields": {"Name": {"rules": [{"name": "minLen"}, {"name": "bogus"}]}}}`,
+			want: []string{"Name: rule minLen takes 1 params, but got 0", "Name: unknown string rule bogus"},
+		},
+		{
+			def:  `{"kind": "struct", "fields": {"Name": {"rules": [{"name": "even"}]}}}`,
+			want: []string{"Name: rule even validates int8, not string"},
+		},
+		{
+			def:  `{"kind": "struct", "fields": {"Tags": {"elem": {"kind": "number"}}}}`,
+			want: []string{"Tags[]: definition of kind number cannot validate string of kind string"},
+		},
+		{
+			def:  `{"kind": "struct", "fields": {"Address.Zip": {"kind": "string"}, "Name.Len": {}}}`,
+			want: []string{"Address.Zip: validconfig_test.Address has no exported field Zip", "Name.Len: string is not a struct"},
+		},
+		{
+			def:  `{"kind": "struct", "bogus": true}`,
+			want: []string{`unknown field "bogus"`},
+		},
+		{
+			// Methods of validators that are not rules cannot be called.
+			def: `{"kind": "struct", "fields": {
+				"Name": {"rules": [{"name": "Validate"}, {"name": "Sealed"}, {"name": "Seal"}, {"name": "maxLen", "params": [5]}]},
+				"Age": {"rules": [{"name": "AsWarning"}, {"name": "seal"}, {"name": "groups", "params": ["a"]}]}
+			}}`,
+			want: []string{
+				"Name: unknown string rule Validate",
+				"Name: unknown string rule Sealed",
+				"Name: unknown string rule Seal",
+				"Age: unknown number rule AsWarning",
+				"Age: unknown number rule seal",
+				"Age: unknown number rule groups",
+			},
+		},
+	}
+
+	for _, test := range tests {
+		_, err := validconfig.Load[User]([]byte(test.def), registry())
+		if err == nil {
+			t.Errorf("expected %s to fail to load", test.def)
+			continue
+		}
+
+		for _, want := range test.want {
+			if !strings.Contains(err.Error(), want) {
+				t.Errorf("expected error for %s to contain %q, got:\n%v", test.def, want, err)
+			}
+		}
+	}
+}
+
+func TestLoadValidateNil(t *testing.T) {
+	v, err := validconfig.Load[User]([]byte(userDefinition), registry())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if got := validtest.Violations(v.ValidateAny(nil)); len(got) != 1 || got[0].Code != validators.CodeType {
+		t.Errorf("expected nil to fail with a type violation, got %v", got)
+	}
+}
+
+func TestLoadMessages(t *testing.T) {
+	v, err := validconfig.Load[User]([]byte(userDefinition), registry())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	user := User{Name: "Ada", Email: "a@b", Age: 22, Score: 0.5, Tags: []string{"a", "b", "c"}}
+
+	var got []string
+	for _, e := range validators.AsErrors(v.Validate(user)) {
+		got = append(got, e.Error())
+	}
+
+	want := []string{
+		"Address: expected (*validconfig_test.Address)(nil) to not be nil",
+		"Tags: expected [a b c] to have max len 2",
+	}
+	if !slices.Equal(got, want) {
+		t.Errorf("expected messages %q, got %q", want, got)
+	}
+
+	x := validators.Explain(v, user)
+	var names []string
+	for _, n := range x.Nested {
+		names = append(names, n.Path+" "+n.Validator)
+	}
+
+	if x.Validator != "validconfig_test.User" || !slices.Contains(names, "Address *validconfig_test.Address") || !slices.Contains(names, "Tags []string") {
+		t.Errorf("expected validators named by the types of the definition, got %s and %q", x.Validator, names)
+	}
+}
+
+func TestMarshalRoundTrip(t *testing.T) {
+	orig := validators.MustStructValidator[User](validators.StructShape{
+		"Name": validators.NewStringValidator[string]().NotEmpty().MaxLen(5),
+		"Age":  validators.NewNumberValidator[int8]().In(30, 40),
+		"Tags": validators.NewSliceValidator[[]string](
+			validators.NewStringValidator[string]().HasPrefix("#"),
+		).AnySatisfy(validators.NewStringValidator[string]().EqualTo("#go")),
+		"Address.City": validators.NewStringValidator[string]().In("Paris"),
+	})
+
+	data, err := validconfig.Marshal(orig)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	loaded, err := validconfig.Load[User](data, nil)
+	if err != nil {
+		t.Fatalf("loading %s: %v", data, err)
+	}
+
+	again, err := validconfig.Marshal(loaded)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if string(again) != string(data) {
+		t.Errorf("expected round trip to preserve definition\n%s\ngot\n%s", data, again)
+	}
+
+	validtest.AssertValid(t, loaded, User{Name: "Ada", Age: 30, Tags: []string{"#go"}})
+	validtest.AssertInvalid(t, loaded, User{Name: "Ada", Age: 30, Tags: []string{"#rust"}}, "Tags", "anySatisfy")
+	validtest.AssertInvalid(t, loaded, User{Name: "Ada", Age: 31}, "Age", "in")
+	validtest.AssertValid(t, loaded, User{Name: "Ada", Age: 30, Tags: []string{"#go"}, Address: &Address{City: "Paris"}})
+	validtest.AssertInvalid(t, loaded, User{Name: "Ada", Age: 30, Tags: []string{"#go"}, Address: &Address{City: "Rome"}}, "Address.City", "in")
+}
+
+func TestMarshalUnsupported(t *testing.T) {
+	v := validators.NewStringValidator[string]().Satisfies(func(string) error { return nil })
+	if _, err := validconfig.Marshal(v); err == nil {
+		t.Errorf("expected satisfies rule not to be serializable")
+	}
+}
+
+func TestLoadGroups(t *testing.T) {
+	v, err := validconfig.Load[User]([]byte(`{
+		"kind": "struct",
+		"fields": {
+			"Name": {"rules": [{"name": "empty", "groups": ["create"]}]},
+			"Tags": {"elem": {"rules": [{"name": "notEmpty", "groups": ["update"]}]}},
+			"Age": {"rules": [{"name": "even", "groups": ["update"]}]}
+		}
+	}`), registry())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	g := v.(interface {
+		ValidateGroups(User, ...string) error
+	})
+
+	user := User{Name: "Ada", Age: 21, Tags: []string{""}}
+	validtest.AssertValid(t, v, user)
+
+	if got := validtest.Violations(g.ValidateGroups(user, "create")); len(got) != 1 || got[0].Code != "empty" {
+		t.Errorf("expected create to fail empty, got %v", got)
+	}
+
+	if got := validtest.Violations(g.ValidateGroups(user, "update")); len(got) != 2 || got[0].Path != "Age" || got[1].Path != "Tags[0]" {
+		t.Errorf("expected update to fail even and notEmpty, got %v", got)
+	}
+
+	data, err := validconfig.Marshal(v)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !strings.Contains(string(data), `"groups":["create"]`) {
+		t.Errorf("expected groups to be serialized, got %s", data)
+	}
+}
+
+func TestLoadWarnings(t *testing.T) {
+	v, err := validconfig.Load[User]([]byte(`{
+		"kind": "struct",
+		"fields": {
+			"Name": {"rules": [{"name": "maxLen", "params": [3], "warning": true}]},
+			"Tags": {"elem": {"rules": [{"name": "notEmpty", "warning": true}]}},
+			"Age": {"rules": [{"name": "even", "warning": true}]}
+		}
+	}`), registry())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	user := User{Name: "Grace", Age: 21, Tags: []string{""}}
+	validtest.AssertValid(t, v, user)
+
+	res := validators.Check(v, user)
+	want := []validtest.Violation{{Path: "Age", Code: "even"}, {Path: "Name", Code: "maxLen"}, {Path: "Tags[0]", Code: "notEmpty"}}
+	if got := validtest.Violations(res.Warnings); res.Err() != nil || !slices.Equal(got, want) {
+		t.Errorf("expected warnings %v and no errors, got %v and %v", want, got, res.Err())
+	}
+
+	data, err := validconfig.Marshal(v)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !strings.Contains(string(data), `"warning":true`) {
+		t.Errorf("expected warnings to be serialized, got %s", data)
+	}
+}
+
+func TestLoadSensitive(t *testing.T) {
+	v, err := validconfig.Load[User]([]byte(`{
+		"kind": "struct",
+		"fields": {
+			"Name": {"sensitive": true, "rules": [{"name": "minLen", "params": [10]}]}
+		}
+	}`), registry())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := v.Validate(User{Name: "Grace", Age: 21}); err == nil || strings.Contains(err.Error(), "Grace") {
+		t.Errorf("expected a redacted error, got %v", err)
+	}
+
+	data, err := validconfig.Marshal(v)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !strings.Contains(string(data), `"sensitive":true`) {
+		t.Errorf("expected sensitive to be serialized, got %s", data)
+	}
+}
@@ -0,0 +1,221 @@
+package validconfig
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/bitcrshr/valid/validators"
+)
+
+// Reloadable is a validator backed by a definition file. It polls the file
+// and, whenever its contents change, loads the new definition and swaps it in
+// atomically. If the new definition fails to load, the previous validator
+// stays active and the error is reported by LastError.
+//
+// Validate may be called concurrently with reloads; every call uses either the
+// old or the new validator in its entirety.
+type Reloadable[T any] struct {
+	path     string
+	registry *Registry
+
+	active atomic.Pointer[loaded[T]]
+
+	mu      sync.Mutex
+	lastErr error
+	modTime time.Time
+	size    int64
+
+	stop chan struct{}
+	done chan struct{}
+}
+
+type loaded[T any] struct {
+	validator validators.Validator[T]
+	version   uint64
+	data      []byte
+}
+
+var _ validators.Validator[int] = &Reloadable[int]{}
+
+// NewReloadable loads the definition at path and checks it for changes every
+// interval until Close is called. It fails if interval is not positive or the
+// initial definition cannot be loaded.
+func NewReloadable[T any](path string, r *Registry, interval time.Duration) (*Reloadable[T], error) {
+	if interval <= 0 {
+		return nil, fmt.Errorf("validconfig: polling interval must be positive, got %v", interval)
+	}
+
+	v := &Reloadable[T]{
+		path:     path,
+		registry: r,
+		stop:     make(chan struct{}),
+		done:     make(chan struct{}),
+	}
+
+	if err := v.Reload(); err != nil {
+		return nil, err
+	}
+
+	go v.poll(interval)
+
+	return v, nil
+}
+
+func (v *Reloadable[T]) Validate(value T) error {
+	return v.active.Load().validator.Validate(value)
+}
+
+func (v *Reloadable[T]) ValidateAny(value any) error {
+	return v.active.Load().validator.ValidateAny(value)
+}
+
+// ValidateGroups validates value with the rules in no group or in one of
+// groups, see validators.Rule.
+func (v *Reloadable[T]) ValidateGroups(value T, groups ...string) error {
+	return v.ValidateAnyGroups(value, groups...)
+}
+
+func (v *Reloadable[T]) ValidateAnyGroups(value any, groups ...string) error {
+	return v.ValidateAnyIn(validators.NewEvaluation(groups...), value)
+}
+
+func (v *Reloadable[T]) ValidateAnyIn(ev *validators.Evaluation, value any) error {
+	active := v.active.Load().validator
+	if e, ok := active.(validators.EvaluationValidator); ok {
+		return e.ValidateAnyIn(ev, value)
+	}
+
+	return active.ValidateAny(value)
+}
+
+// Check validates value like ValidateGroups and also reports the violations
+// of rules marked as warnings, see validators.Check.
+func (v *Reloadable[T]) Check(value T, groups ...string) validators.Result {
+	return validators.Check(v, value, groups...)
+}
+
+// Explain traces the validation of value by the active validator, see
+// validators.Explain.
+func (v *Reloadable[T]) Explain(value T, groups ...string) *validators.Explanation {
+	return validators.Explain(v, value, groups...)
+}
+
+func (v *Reloadable[T]) Describe() validators.Description {
+	return validators.Describe(v.active.Load().validator)
+}
+
+// String renders the active validator, see validators.Description.String.
+func (v *Reloadable[T]) String() string {
+	return v.Describe().String()
+}
+
+// Version returns the number of definitions that have been loaded
+// successfully, starting at 1 for the initial one.
+func (v *Reloadable[T]) Version() uint64 {
+	return v.active.Load().version
+}
+
+// LastError returns the error of the most recent reload, or nil if it
+// succeeded.
+func (v *Reloadable[T]) LastError() error {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+
+	return v.lastErr
+}
+
+// Reload loads the definition file immediately, regardless of whether it has
+// changed since it was last loaded.
+func (v *Reloadable[T]) Reload() error {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+
+	info, err := os.Stat(v.path)
+	if err != nil {
+		return v.fail(err)
+	}
+
+	return v.reload(info)
+}
+
+// Close stops polling the definition file. The validator remains usable.
+func (v *Reloadable[T]) Close() {
+	close(v.stop)
+	<-v.done
+}
+
+func (v *Reloadable[T]) poll(interval time.Duration) {
+	defer close(v.done)
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-v.stop:
+			return
+		case <-ticker.C:
+			v.check()
+		}
+	}
+}
+
+// check reloads the definition file if it was modified since it was last
+// loaded.
+func (v *Reloadable[T]) check() {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+
+	info, err := os.Stat(v.path)
+	if err != nil {
+		_ = v.fail(err)
+		return
+	}
+
+	if info.ModTime().Equal(v.modTime) && info.Size() == v.size {
+		return
+	}
+
+	_ = v.reload(info)
+}
+
+// reload must be called with mu held.
+func (v *Reloadable[T]) reload(info os.FileInfo) error {
+	v.modTime, v.size = info.ModTime(), info.Size()
+
+	data, err := os.ReadFile(v.path)
+	if err != nil {
+		return v.fail(err)
+	}
+
+	prev := v.active.Load()
+	if prev != nil && bytes.Equal(prev.data, data) {
+		v.lastErr = nil
+		return nil
+	}
+
+	validator, err := Load[T](data, v.registry)
+	if err != nil {
+		return v.fail(err)
+	}
+
+	next := &loaded[T]{validator: validator, version: 1, data: data}
+	if prev != nil {
+		next.version = prev.version + 1
+	}
+
+	v.active.Store(next)
+	v.lastErr = nil
+
+	return nil
+}
+
+// fail must be called with mu held.
+func (v *Reloadable[T]) fail(err error) error {
+	v.lastErr = fmt.Errorf("validconfig: reloading %s: %w", v.path, err)
+	return v.lastErr
+}
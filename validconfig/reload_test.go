@@ -0,0 +1,133 @@
+package validconfig_test
+
+import (
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/bitcrshr/valid/validconfig"
+)
+
+func writeDefinition(t *testing.T, path, maxLen string) {
+	t.Helper()
+
+	def := `{"kind": "struct", "fields": {"Name": {"kind": "string", "rules": [{"name": "maxLen", "params": [` + maxLen + `]}]}}}`
+	if err := os.WriteFile(path, []byte(def), 0o600); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func waitFor(t *testing.T, cond func() bool) {
+	t.Helper()
+
+	deadline := time.Now().Add(5 * time.Second)
+	for !cond() {
+		if time.Now().After(deadline) {
+			t.Fatal("timed out waiting for reload")
+		}
+
+		time.Sleep(time.Millisecond)
+	}
+}
+
+func TestReloadable(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "user.json")
+	writeDefinition(t, path, "5")
+
+	v, err := validconfig.NewReloadable[User](path, nil, time.Millisecond)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer v.Close()
+
+	if v.Version() != 1 {
+		t.Errorf("expected version 1, got %d", v.Version())
+	}
+
+	long := User{Name: "Grace Hopper"}
+	if err := v.Validate(long); err == nil {
+		t.Errorf("expected %q to exceed the initial max len", long.Name)
+	}
+
+	// Validate concurrently with reloads, which the race detector checks.
+	var wg sync.WaitGroup
+	stop := make(chan struct{})
+	for range 4 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for {
+				select {
+				case <-stop:
+					return
+				default:
+					_ = v.Validate(long)
+				}
+			}
+		}()
+	}
+
+	writeDefinition(t, path, "50")
+	waitFor(t, func() bool { return v.Version() == 2 })
+
+	close(stop)
+	wg.Wait()
+
+	if err := v.Validate(long); err != nil {
+		t.Errorf("expected %q to pass after reload, got %v", long.Name, err)
+	}
+
+	writeDefinition(t, path, `"fifty"`)
+	waitFor(t, func() bool { return v.LastError() != nil })
+
+	if v.Version() != 2 {
+		t.Errorf("expected failed reload to keep version 2, got %d", v.Version())
+	}
+
+	if err := v.Validate(long); err != nil {
+		t.Errorf("expected failed reload to keep the previous validator, got %v", err)
+	}
+
+	// A rule that names a builder method which is not a rule must not take
+	// down the polling goroutine.
+	if err := os.WriteFile(path, []byte(`{"kind": "struct", "fields": {"Name": {"kind": "string", "rules": [{"name": "Sealed"}]}}}`), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := v.Reload(); err == nil || v.Version() != 2 {
+		t.Errorf("expected a definition with a bad rule name to fail to reload, got %v, %d", err, v.Version())
+	}
+
+	writeDefinition(t, path, "3")
+	if err := v.Reload(); err != nil {
+		t.Fatal(err)
+	}
+
+	if v.LastError() != nil || v.Version() != 3 {
+		t.Errorf("expected successful reload to clear the error and bump the version, got %v, %d", v.LastError(), v.Version())
+	}
+
+	if err := v.Reload(); err != nil || v.Version() != 3 {
+		t.Errorf("expected reloading an unchanged file to keep the version, got %v, %d", err, v.Version())
+	}
+}
+
+func TestReloadableInitialError(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "user.json")
+
+	if _, err := validconfig.NewReloadable[User](path, nil, time.Millisecond); err == nil {
+		t.Errorf("expected missing file to fail")
+	}
+
+	writeDefinition(t, path, "-")
+	if _, err := validconfig.NewReloadable[User](path, nil, time.Millisecond); err == nil {
+		t.Errorf("expected invalid definition to fail")
+	}
+
+	writeDefinition(t, path, "5")
+	if _, err := validconfig.NewReloadable[User](path, nil, 0); err == nil {
+		t.Errorf("expected a zero interval to fail")
+	}
+}
@@ -0,0 +1,329 @@
+// Package validdoc generates documentation of the constraints validators
+// enforce, as Markdown or HTML tables listing the path, type and constraints
+// of every field, from the descriptions of the validators, see
+// validators.Describe.
+//
+// To keep the documentation of API types up to date with go generate, add a
+// program that calls Main, e.g. in gendoc/main.go:
+//
+//	package main
+//
+//	func main() {
+//		validdoc.Main(
+//			validdoc.Doc{Name: "User", Validator: api.UserValidator()},
+//			validdoc.Doc{Name: "Order", Validator: api.OrderValidator()},
+//		)
+//	}
+//
+// and run it from a go:generate directive:
+//
+//	//go:generate go run ./gendoc -o API.md
+package validdoc
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"html/template"
+	"io"
+	"maps"
+	"os"
+	"path/filepath"
+	"reflect"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/bitcrshr/valid/validators"
+)
+
+// Doc is a validator documented under a name, such as that of the type it
+// validates.
+type Doc struct {
+	Name      string
+	Validator validators.AnyValidator
+}
+
+// Field is a row of the documentation of a validator.
+type Field struct {
+	// Path is the path to the field, like the paths of validators.Error,
+	// with "[]" for the elements of slices, e.g. "Tags[]", or "" for the
+	// validated value itself.
+	Path string
+	// Type is the Go type of the field, without package qualifiers, e.g.
+	// "*Address".
+	Type        string
+	Constraints []string
+}
+
+// Fields returns the fields of the values v validates, ordered by path, with
+// the constraints v enforces on each. Fields whose validators have no rules
+// are included, unless they are structs, slices or pointers, which are
+// documented by their fields and elements instead. Constraints on a pointer
+// and on the value it points to are listed together.
+func Fields(v validators.AnyValidator) []Field {
+	var fields []Field
+	appendFields(&fields, validators.Describe(v), "")
+
+	return fields
+}
+
+func appendFields(fields *[]Field, d validators.Description, path string) {
+	constraints := make([]string, 0, len(d.Rules))
+	for _, r := range d.Rules {
+		constraints = append(constraints, Constraint(r))
+	}
+
+	if d.Sensitive {
+		constraints = append(constraints, "sensitive")
+	}
+
+	switch last := len(*fields) - 1; {
+	case last >= 0 && (*fields)[last].Path == path:
+		(*fields)[last].Constraints = append((*fields)[last].Constraints, constraints...)
+	case len(constraints) > 0 || (d.Elem == nil && d.Fields == nil):
+		*fields = append(*fields, Field{Path: path, Type: unqualified(d.Type), Constraints: constraints})
+	}
+
+	if d.Elem != nil {
+		elemPath := path
+		if d.Kind == "slice" {
+			elemPath += "[]"
+		}
+
+		appendFields(fields, *d.Elem, elemPath)
+	}
+
+	for _, name := range slices.Sorted(maps.Keys(d.Fields)) {
+		fieldPath := name
+		if path != "" {
+			fieldPath = path + "." + name
+		}
+
+		appendFields(fields, d.Fields[name], fieldPath)
+	}
+}
+
+var qualifier = regexp.MustCompile(`[\w/.-]*\.`)
+
+// unqualified returns t, the name of a type, without package qualifiers.
+func unqualified(t string) string {
+	return qualifier.ReplaceAllString(t, "")
+}
+
+// Constraint describes the rule r in words, e.g. "length at least 5", for
+// documentation. Rules it does not know, such as custom rules of
+// validconfig, are described by their name and params.
+func Constraint(r validators.Rule) string {
+	p := make([]string, len(r.Params))
+	for i, param := range r.Params {
+		p[i] = formatParam(param)
+	}
+
+	var s string
+	switch phrase, known := phrases[r.Name]; {
+	case !known && len(p) > 0:
+		s = r.Name + "(" + strings.Join(p, ", ") + ")"
+	case !known:
+		s = r.Name
+	case strings.Contains(phrase, "%[2]s") && len(p) == 2:
+		s = fmt.Sprintf(phrase, p[0], p[1])
+	case strings.Contains(phrase, "%s"):
+		s = fmt.Sprintf(phrase, strings.Join(p, ", "))
+	default:
+		s = phrase
+	}
+
+	if len(r.Groups) > 0 {
+		s += " (only in " + strings.Join(r.Groups, ", ") + ")"
+	}
+
+	if r.Warning {
+		s += " (warning)"
+	}
+
+	return s
+}
+
+// phrases describe the built-in rules. Params are formatted and, unless the
+// phrase refers to the second one, joined with commas.
+var phrases = map[string]string{
+	"empty":           "empty",
+	"notEmpty":        "not empty",
+	"len":             "length exactly %s",
+	"minLen":          "length at least %s",
+	"maxLen":          "length at most %s",
+	"equalTo":         "equal to %s",
+	"notEqualTo":      "not equal to %s",
+	"hasPrefix":       "starts with %s",
+	"notHasPrefix":    "does not start with %s",
+	"hasSuffix":       "ends with %s",
+	"notHasSuffix":    "does not end with %s",
+	"contains":        "contains %s",
+	"notContains":     "does not contain %s",
+	"containsAtLeast": "contains %[1]s at least %[2]s times",
+	"containsAtMost":  "contains %[1]s at most %[2]s times",
+	"containsExact":   "contains %[1]s exactly %[2]s times",
+	"in":              "one of %s",
+	"notIn":           "none of %s",
+	"matches":         "matches %s",
+	"notMatches":      "does not match %s",
+	"uuid":            "UUID",
+	"positive":        "at least 0",
+	"negative":        "at most 0",
+	"zero":            "zero",
+	"nonZero":         "not zero",
+	"notZero":         "not zero",
+	"gt":              "greater than %s",
+	"gte":             "at least %s",
+	"lt":              "less than %s",
+	"lte":             "at most %s",
+	"hasKey":          "has key %s",
+	"notHasKey":       "does not have key %s",
+	"hasKeyIn":        "has one of the keys %s",
+	"notHasKeyIn":     "has none of the keys %s",
+	"nil":             "nil",
+	"notNil":          "required",
+	"allSatisfy":      "every element: %s",
+	"anySatisfy":      "some element: %s",
+	"noneSatisfy":     "no element: %s",
+	"satisfies":       "custom check",
+}
+
+// formatParam formats p, a param of a rule. Strings and patterns are quoted,
+// and validators, passed to rules like AllSatisfy, are described by their
+// constraints.
+func formatParam(p any) string {
+	switch p := p.(type) {
+	case validators.Description:
+		cs := make([]string, len(p.Rules))
+		for i, r := range p.Rules {
+			cs[i] = Constraint(r)
+		}
+
+		if len(cs) == 0 {
+			return "any"
+		}
+
+		return strings.Join(cs, ", ")
+	case *regexp.Regexp:
+		return "`" + p.String() + "`"
+	}
+
+	if reflect.ValueOf(p).Kind() == reflect.String {
+		return strconv.Quote(fmt.Sprint(p))
+	}
+
+	return fmt.Sprint(p)
+}
+
+// Markdown writes a section with a table of the fields of each of docs to w,
+// see Fields.
+func Markdown(w io.Writer, docs ...Doc) error {
+	var b bytes.Buffer
+	b.WriteString(generated)
+
+	for _, doc := range docs {
+		fmt.Fprintf(&b, "\n## %s\n\n| Field | Type | Constraints |\n| --- | --- | --- |\n", doc.Name)
+		for _, f := range Fields(doc.Validator) {
+			fmt.Fprintf(&b, "| %s | %s | %s |\n", markdownCode(displayPath(f.Path)), markdownCode(f.Type), markdownCell(strings.Join(f.Constraints, "; ")))
+		}
+	}
+
+	_, err := w.Write(b.Bytes())
+
+	return err
+}
+
+func markdownCode(s string) string {
+	return "`" + markdownCell(s) + "`"
+}
+
+// markdownCell escapes s for a cell of a Markdown table.
+func markdownCell(s string) string {
+	return strings.NewReplacer("|", `\|`, "<", "&lt;", "\n", " ").Replace(s)
+}
+
+// generated marks the output as generated, see go help generate.
+const generated = "<!-- Code generated by validdoc. DO NOT EDIT. -->\n"
+
+var page = template.Must(template.New("validdoc").Parse(`{{range .}}
+<h2>{{.Name}}</h2>
+<table>
+<thead><tr><th>Field</th><th>Type</th><th>Constraints</th></tr></thead>
+<tbody>
+{{- range .Fields}}
+<tr><td><code>{{.Path}}</code></td><td><code>{{.Type}}</code></td><td>
+{{- range $i, $c := .Constraints}}{{if $i}}<br>{{end}}{{$c}}{{end -}}
+</td></tr>
+{{- end}}
+</tbody>
+</table>
+{{- end}}
+`))
+
+// HTML writes a heading and a table of the fields of each of docs to w, see
+// Fields. The output is a fragment meant to be included in a page.
+func HTML(w io.Writer, docs ...Doc) error {
+	type section struct {
+		Name   string
+		Fields []Field
+	}
+
+	sections := make([]section, len(docs))
+	for i, doc := range docs {
+		fields := Fields(doc.Validator)
+		for j := range fields {
+			fields[j].Path = displayPath(fields[j].Path)
+		}
+
+		sections[i] = section{Name: doc.Name, Fields: fields}
+	}
+
+	if _, err := io.WriteString(w, generated); err != nil {
+		return err
+	}
+
+	return page.Execute(w, sections)
+}
+
+// displayPath returns path, or "(value)" for the validated value itself.
+func displayPath(path string) string {
+	if path == "" {
+		return "(value)"
+	}
+
+	return path
+}
+
+// Main writes the documentation of docs and exits, for programs run by go
+// generate. The output is written to the file given with -o, or to standard
+// output, as HTML if -html is given or the file name ends in .html, and as
+// Markdown otherwise.
+func Main(docs ...Doc) {
+	out := flag.String("o", "", "write the documentation to `file` instead of standard output")
+	asHTML := flag.Bool("html", false, "write HTML instead of Markdown")
+	flag.Parse()
+
+	write := Markdown
+	if *asHTML || strings.EqualFold(filepath.Ext(*out), ".html") {
+		write = HTML
+	}
+
+	var b bytes.Buffer
+	if err := write(&b, docs...); err != nil {
+		fmt.Fprintln(os.Stderr, "validdoc:", err)
+		os.Exit(1)
+	}
+
+	if *out == "" {
+		_, _ = os.Stdout.Write(b.Bytes())
+		return
+	}
+
+	if err := os.WriteFile(*out, b.Bytes(), 0o644); err != nil {
+		fmt.Fprintln(os.Stderr, "validdoc:", err)
+		os.Exit(1)
+	}
+}
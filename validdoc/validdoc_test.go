@@ -0,0 +1,99 @@
+package validdoc_test
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/bitcrshr/valid/validators"
+	"github.com/bitcrshr/valid/validdoc"
+)
+
+type Address struct {
+	City string
+}
+
+type User struct {
+	Name    string
+	Email   string
+	Age     int
+	Tags    []string
+	Address *Address
+}
+
+func userValidator() validators.AnyValidator {
+	return validators.NewPointerValidator(validators.MustStructValidator[User](validators.StructShape{
+		"Name":  validators.NewStringValidator[string]().MinLen(2).MaxLen(20).AsWarning(),
+		"Email": validators.NewStringValidator[string]().ContainsExact("@", 1).Sensitive(),
+		"Age":   validators.NewNumberValidator[int]().GTE(18).Groups("adult"),
+		"Tags": validators.NewSliceValidator[[]string](validators.NewStringValidator[string]().In("a|b", "<c>")).
+			MaxLen(3).
+			AllSatisfy(validators.NewStringValidator[string]().NotEmpty()),
+		"Address": validators.NewPointerValidator(validators.MustStructValidator[Address](validators.StructShape{
+			"City": validators.NewStringValidator[string](),
+		})).NotNil(),
+	})).NotNil()
+}
+
+func TestMarkdown(t *testing.T) {
+	var b bytes.Buffer
+	if err := validdoc.Markdown(&b, validdoc.Doc{Name: "User", Validator: userValidator()}); err != nil {
+		t.Fatal(err)
+	}
+
+	want := "<!-- Code generated by validdoc. DO NOT EDIT. -->\n" +
+		"\n" +
+		"## User\n" +
+		"\n" +
+		"| Field | Type | Constraints |\n" +
+		"| --- | --- | --- |\n" +
+		"| `(value)` | `*User` | required |\n" +
+		"| `Address` | `*Address` | required |\n" +
+		"| `Address.City` | `string` |  |\n" +
+		"| `Age` | `int` | at least 18 (only in adult) |\n" +
+		"| `Email` | `string` | contains \"@\" exactly 1 times; sensitive |\n" +
+		"| `Name` | `string` | length at least 2; length at most 20 (warning) |\n" +
+		"| `Tags` | `[]string` | length at most 3; every element: not empty |\n" +
+		"| `Tags[]` | `string` | one of \"a\\|b\", \"&lt;c>\" |\n"
+	if got := b.String(); got != want {
+		t.Errorf("expected\n%s\ngot\n%s", want, got)
+	}
+}
+
+func TestHTML(t *testing.T) {
+	var b bytes.Buffer
+	if err := validdoc.HTML(&b, validdoc.Doc{Name: "User", Validator: userValidator()}); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, want := range []string{
+		"<!-- Code generated by validdoc. DO NOT EDIT. -->\n\n<h2>User</h2>",
+		"<tr><td><code>(value)</code></td><td><code>*User</code></td><td>required</td></tr>",
+		"<tr><td><code>Name</code></td><td><code>string</code></td><td>length at least 2<br>length at most 20 (warning)</td></tr>",
+		"<td>one of &#34;a|b&#34;, &#34;&lt;c&gt;&#34;</td>",
+	} {
+		if !strings.Contains(b.String(), want) {
+			t.Errorf("expected HTML to contain %q, got:\n%s", want, b.String())
+		}
+	}
+}
+
+func TestConstraint(t *testing.T) {
+	tests := []struct {
+		rule validators.Rule
+		want string
+	}{
+		{validators.Rule{Name: "minLen", Params: []any{5}}, "length at least 5"},
+		{validators.Rule{Name: "containsAtMost", Params: []any{"-", 2}}, `contains "-" at most 2 times`},
+		{validators.Rule{Name: "uuid", Groups: []string{"update", "delete"}}, "UUID (only in update, delete)"},
+		{validators.Rule{Name: "satisfies"}, "custom check"},
+		{validators.Rule{Name: "isEven"}, "isEven"},
+		{validators.Rule{Name: "between", Params: []any{1, 9}}, "between(1, 9)"},
+	}
+
+	for _, test := range tests {
+		if got := validdoc.Constraint(test.rule); got != test.want {
+			t.Errorf("expected %v to be described as %q, got %q", test.rule, test.want, got)
+		}
+	}
+}
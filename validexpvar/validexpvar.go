@@ -0,0 +1,72 @@
+// Package validexpvar publishes counters and timings of validations as expvar
+// variables, see validators.Observer.
+package validexpvar
+
+import (
+	"expvar"
+	"regexp"
+
+	"github.com/bitcrshr/valid/validators"
+)
+
+// Observer is a validators.Observer that counts validations and rule
+// failures in expvar maps.
+type Observer struct {
+	validations *expvar.Map
+	invalid     *expvar.Map
+	nanoseconds *expvar.Map
+	failures    *expvar.Map
+	paths       *expvar.Map
+}
+
+var _ validators.Observer = &Observer{}
+
+// New returns an Observer that publishes an expvar.Map named name, which holds
+// the maps:
+//
+//   - "validations": the number of validations by validator, e.g. "string"
+//   - "invalid": the number of those that failed, by validator
+//   - "nanoseconds": their total duration by validator
+//   - "failures": the number of rule failures by code, e.g. "minLen"
+//   - "failuresByPath": the number of rule failures by path and code, e.g.
+//     "Tags[]:minLen", or ":notNil" for the value validation started with
+//
+// Indices in paths are dropped, so that the number of keys stays bounded. Like
+// expvar.NewMap, New panics if name is already in use.
+//
+// Install the observer with validators.SetObserver:
+//
+//	validators.SetObserver(validexpvar.New("validation"))
+func New(name string) *Observer {
+	o := &Observer{
+		validations: new(expvar.Map),
+		invalid:     new(expvar.Map),
+		nanoseconds: new(expvar.Map),
+		failures:    new(expvar.Map),
+		paths:       new(expvar.Map),
+	}
+
+	m := expvar.NewMap(name)
+	m.Set("validations", o.validations)
+	m.Set("invalid", o.invalid)
+	m.Set("nanoseconds", o.nanoseconds)
+	m.Set("failures", o.failures)
+	m.Set("failuresByPath", o.paths)
+
+	return o
+}
+
+func (o *Observer) Validated(e validators.ValidationEvent) {
+	o.validations.Add(e.Validator, 1)
+	o.nanoseconds.Add(e.Validator, e.Duration.Nanoseconds())
+	if e.Err != nil {
+		o.invalid.Add(e.Validator, 1)
+	}
+}
+
+func (o *Observer) Failed(e validators.FailureEvent) {
+	o.failures.Add(e.Code, 1)
+	o.paths.Add(index.ReplaceAllString(e.Path, "[]")+":"+e.Code, 1)
+}
+
+var index = regexp.MustCompile(`\[\d+\]`)
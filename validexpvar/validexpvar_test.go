@@ -0,0 +1,63 @@
+package validexpvar_test
+
+import (
+	"expvar"
+	"testing"
+
+	"github.com/bitcrshr/valid/validators"
+	"github.com/bitcrshr/valid/validexpvar"
+)
+
+type Post struct {
+	Title string
+	Tags  []string
+}
+
+func TestObserver(t *testing.T) {
+	validators.SetObserver(validexpvar.New("valid_test"))
+	defer validators.SetObserver(nil)
+
+	v := validators.MustStructValidator[Post](validators.StructShape{
+		"Title": validators.NewStringValidator[string]().NotEmpty(),
+		"Tags":  validators.NewSliceValidator[[]string](validators.NewStringValidator[string]().MinLen(2)),
+	})
+
+	_ = v.Validate(Post{Title: "a", Tags: []string{"go"}})
+	_ = v.Validate(Post{Tags: []string{"x", "y", "go"}})
+
+	m := expvar.Get("valid_test").(*expvar.Map)
+	get := func(name, key string) int64 {
+		t.Helper()
+
+		c, ok := m.Get(name).(*expvar.Map).Get(key).(*expvar.Int)
+		if !ok {
+			t.Fatalf("expected %s[%q] to be set, got %s", name, key, m.Get(name))
+		}
+
+		return c.Value()
+	}
+
+	tests := []struct {
+		name, key string
+		want      int64
+	}{
+		{"validations", "validexpvar_test.Post", 2},
+		{"invalid", "validexpvar_test.Post", 1},
+		{"validations", "string", 6},
+		{"invalid", "string", 3},
+		{"failures", "minLen", 2},
+		{"failures", "notEmpty", 1},
+		{"failuresByPath", "Tags[]:minLen", 2},
+		{"failuresByPath", "Title:notEmpty", 1},
+	}
+
+	for _, test := range tests {
+		if got := get(test.name, test.key); got != test.want {
+			t.Errorf("expected %s[%q] to be %d, got %d", test.name, test.key, test.want, got)
+		}
+	}
+
+	if get("nanoseconds", "string") <= 0 {
+		t.Errorf("expected validations to be timed")
+	}
+}
@@ -0,0 +1,381 @@
+package validtest
+
+import (
+	"fmt"
+	"math/rand"
+	"reflect"
+	"testing"
+	"testing/quick"
+
+	"github.com/bitcrshr/valid/validators"
+)
+
+// Arbitrary generates values for property based tests from the rules of a
+// validator: values that pass it, for fuzzing code guarded by the validator,
+// and near misses that violate a single rule, for checking that the validator
+// rejects them.
+//
+// Generation understands the built-in rules of the string, number, slice,
+// pointer, map and struct validators. Rules it cannot reason about, such as
+// Satisfies checks and custom validators, are handled by generating
+// candidates and discarding the ones the validator rejects.
+type Arbitrary[T any] struct {
+	v validators.Validator[T]
+	t reflect.Type
+}
+
+func NewArbitrary[T any](v validators.Validator[T]) *Arbitrary[T] {
+	return &Arbitrary[T]{
+		v: v,
+		t: reflect.TypeFor[T](),
+	}
+}
+
+// Valid returns a random value that passes the validator, or an error if no
+// such value could be found.
+func (a *Arbitrary[T]) Valid(r *rand.Rand) (T, error) {
+	val, err := (&generator{r: r}).valid(a.t, a.v)
+	if err != nil {
+		var zero T
+		return zero, err
+	}
+
+	return val.Interface().(T), nil
+}
+
+// Invalid returns values that fail the validator, each derived from a valid
+// value by violating one of its rules at its boundary, e.g. a string one
+// byte longer than MaxLen allows.
+func (a *Arbitrary[T]) Invalid(r *rand.Rand) []T {
+	vals := (&generator{r: r}).invalid(a.t, a.v)
+
+	invalid := make([]T, len(vals))
+	for i, val := range vals {
+		invalid[i] = val.Interface().(T)
+	}
+
+	return invalid
+}
+
+// Shrink returns the smallest value it can find that still passes the
+// validator and for which fails returns true, starting from value.
+func (a *Arbitrary[T]) Shrink(value T, fails func(T) bool) T {
+	g := &generator{}
+
+	val := reflect.ValueOf(&value).Elem()
+	for range maxShrinkSteps {
+		shrunk := false
+		for _, c := range g.shrinks(a.t, a.v, val) {
+			if passes(a.v, c) && fails(c.Interface().(T)) {
+				val, shrunk = c, true
+				break
+			}
+		}
+
+		if !shrunk {
+			break
+		}
+	}
+
+	return val.Interface().(T)
+}
+
+// Check calls prop with n valid values and reports the smallest failing value
+// it can find if prop returns false for any of them.
+func (a *Arbitrary[T]) Check(t testing.TB, r *rand.Rand, n int, prop func(T) bool) bool {
+	t.Helper()
+
+	for range n {
+		value, err := a.Valid(r)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if !prop(value) {
+			shrunk := a.Shrink(value, func(value T) bool { return !prop(value) })
+			t.Errorf("property failed for %#v (shrunk from %#v)", shrunk, value)
+
+			return false
+		}
+	}
+
+	return true
+}
+
+// QuickConfig returns a configuration for testing/quick.Check that fills the
+// arguments of the function under test with valid values. Every argument of
+// that function must be of type T.
+func (a *Arbitrary[T]) QuickConfig(r *rand.Rand) *quick.Config {
+	return &quick.Config{
+		Rand: r,
+		Values: func(args []reflect.Value, r *rand.Rand) {
+			g := &generator{r: r}
+			for i := range args {
+				val, err := g.valid(a.t, a.v)
+				if err != nil {
+					panic(err)
+				}
+
+				args[i] = val
+			}
+		},
+	}
+}
+
+// Seed adds n valid values and every value returned by Invalid to the seed
+// corpus of f. T must have an underlying type supported by testing.F, such as
+// string or int64, and seeds are added as that underlying type.
+func (a *Arbitrary[T]) Seed(f *testing.F, r *rand.Rand, n int) {
+	f.Helper()
+
+	basic, ok := fuzzTypes[a.t.Kind()]
+	if !ok {
+		f.Fatalf("validtest: %s cannot be used as a fuzz argument", a.t)
+	}
+
+	for range n {
+		value, err := a.Valid(r)
+		if err != nil {
+			f.Fatal(err)
+		}
+
+		f.Add(reflect.ValueOf(value).Convert(basic).Interface())
+	}
+
+	for _, value := range a.Invalid(r) {
+		f.Add(reflect.ValueOf(value).Convert(basic).Interface())
+	}
+}
+
+var fuzzTypes = map[reflect.Kind]reflect.Type{
+	reflect.String:  reflect.TypeFor[string](),
+	reflect.Bool:    reflect.TypeFor[bool](),
+	reflect.Int:     reflect.TypeFor[int](),
+	reflect.Int8:    reflect.TypeFor[int8](),
+	reflect.Int16:   reflect.TypeFor[int16](),
+	reflect.Int32:   reflect.TypeFor[int32](),
+	reflect.Int64:   reflect.TypeFor[int64](),
+	reflect.Uint:    reflect.TypeFor[uint](),
+	reflect.Uint8:   reflect.TypeFor[uint8](),
+	reflect.Uint16:  reflect.TypeFor[uint16](),
+	reflect.Uint32:  reflect.TypeFor[uint32](),
+	reflect.Uint64:  reflect.TypeFor[uint64](),
+	reflect.Float32: reflect.TypeFor[float32](),
+	reflect.Float64: reflect.TypeFor[float64](),
+}
+
+const (
+	maxAttempts    = 100
+	maxShrinkSteps = 1000
+)
+
+// generator builds values of a reflect.Type guided by the validator for that
+// type, which may be nil when the value is unconstrained.
+type generator struct {
+	r *rand.Rand
+}
+
+func (g *generator) valid(t reflect.Type, v validators.AnyValidator) (reflect.Value, error) {
+	for range maxAttempts {
+		val, ok := g.candidate(t, v)
+		if ok && passes(v, val) {
+			return val, nil
+		}
+	}
+
+	return reflect.Value{}, fmt.Errorf("validtest: could not generate a valid %s after %d attempts", t, maxAttempts)
+}
+
+// candidate returns a value that satisfies the rules of v it understands.
+func (g *generator) candidate(t reflect.Type, v validators.AnyValidator) (reflect.Value, bool) {
+	rules := rulesOf(v)
+
+	switch t.Kind() {
+	case reflect.String:
+		return g.str(t, rules)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr,
+		reflect.Float32, reflect.Float64:
+		return g.number(t, rules)
+	case reflect.Slice:
+		return g.slice(t, v, rules)
+	case reflect.Pointer:
+		return g.pointer(t, v, rules)
+	case reflect.Map:
+		return g.mapping(t, rules)
+	case reflect.Struct:
+		return g.structure(t, v)
+	default:
+		if val, ok := quick.Value(t, g.r); ok {
+			return val, true
+		}
+
+		return reflect.Zero(t), true
+	}
+}
+
+// invalid returns near misses for every rule of v, keeping only the ones v
+// actually rejects.
+func (g *generator) invalid(t reflect.Type, v validators.AnyValidator) []reflect.Value {
+	if v == nil {
+		return nil
+	}
+
+	base, err := g.valid(t, v)
+	if err != nil {
+		base = reflect.Zero(t)
+	}
+
+	var candidates []reflect.Value
+	switch t.Kind() {
+	case reflect.String:
+		candidates = g.invalidStr(t, rulesOf(v), base)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr,
+		reflect.Float32, reflect.Float64:
+		candidates = g.invalidNumber(t, rulesOf(v))
+	case reflect.Slice:
+		candidates = g.invalidSlice(t, v, base)
+	case reflect.Pointer:
+		candidates = g.invalidPointer(t, v, base)
+	case reflect.Map:
+		candidates = g.invalidMap(t, rulesOf(v), base)
+	case reflect.Struct:
+		candidates = g.invalidStruct(t, v, base)
+	}
+
+	invalid := make([]reflect.Value, 0, len(candidates))
+	for _, c := range candidates {
+		if !passes(v, c) {
+			invalid = append(invalid, c)
+		}
+	}
+
+	return invalid
+}
+
+// shrinks returns values that are smaller than val in some way, without
+// regard to whether they pass v.
+func (g *generator) shrinks(t reflect.Type, v validators.AnyValidator, val reflect.Value) []reflect.Value {
+	switch t.Kind() {
+	case reflect.String:
+		return shrinkStr(t, val)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr,
+		reflect.Float32, reflect.Float64:
+		return shrinkNumber(t, val)
+	case reflect.Slice:
+		return g.shrinkSlice(t, v, val)
+	case reflect.Pointer:
+		return g.shrinkPointer(t, v, val)
+	case reflect.Map:
+		return shrinkMap(t, val)
+	case reflect.Struct:
+		return g.shrinkStruct(t, v, val)
+	default:
+		return nil
+	}
+}
+
+func passes(v validators.AnyValidator, val reflect.Value) bool {
+	return v == nil || v.ValidateAny(val.Interface()) == nil
+}
+
+// rulesOf returns the rules of v that ValidateAny checks, which excludes
+// those in validation groups and warnings.
+func rulesOf(v validators.AnyValidator) []validators.Rule {
+	d, ok := v.(interface{ Rules() []validators.Rule })
+	if !ok {
+		return nil
+	}
+
+	var rules []validators.Rule
+	for _, r := range d.Rules() {
+		if len(r.Groups) == 0 && !r.Warning {
+			rules = append(rules, r)
+		}
+	}
+
+	return rules
+}
+
+// elemOf returns the element validator of slice and pointer validators.
+func elemOf(v validators.AnyValidator) validators.AnyValidator {
+	if v == nil {
+		return nil
+	}
+
+	m := reflect.ValueOf(v).MethodByName("ElemValidator")
+	if !m.IsValid() || m.Type().NumIn() != 0 || m.Type().NumOut() != 1 {
+		return nil
+	}
+
+	elem, _ := m.Call(nil)[0].Interface().(validators.AnyValidator)
+
+	return elem
+}
+
+func shapeOf(v validators.AnyValidator) validators.StructShape {
+	if s, ok := v.(interface{ Shape() validators.StructShape }); ok {
+		return s.Shape()
+	}
+
+	return nil
+}
+
+// allOf combines validators that must all pass, so that generation takes the
+// rules of each of them into account.
+type allOf []validators.AnyValidator
+
+func (vs allOf) ValidateAny(value any) error {
+	for _, v := range vs {
+		if v == nil {
+			continue
+		}
+
+		if err := v.ValidateAny(value); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (vs allOf) Rules() []validators.Rule {
+	var rules []validators.Rule
+	for _, v := range vs {
+		rules = append(rules, rulesOf(v)...)
+	}
+
+	return rules
+}
+
+func (vs allOf) ElemValidator() validators.AnyValidator {
+	var elems allOf
+	for _, v := range vs {
+		if elem := elemOf(v); elem != nil {
+			elems = append(elems, elem)
+		}
+	}
+
+	if len(elems) == 0 {
+		return nil
+	}
+
+	return elems
+}
+
+func (vs allOf) Shape() validators.StructShape {
+	shape := validators.StructShape{}
+	for _, v := range vs {
+		for name, fv := range shapeOf(v) {
+			if prev, ok := shape[name]; ok {
+				fv = allOf{prev, fv}
+			}
+
+			shape[name] = fv
+		}
+	}
+
+	return shape
+}
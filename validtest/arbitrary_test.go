@@ -0,0 +1,185 @@
+package validtest_test
+
+import (
+	"math"
+	"math/rand"
+	"regexp"
+	"strings"
+	"testing"
+	"testing/quick"
+
+	"github.com/bitcrshr/valid/validators"
+	"github.com/bitcrshr/valid/validtest"
+)
+
+func checkArbitrary[T any](t *testing.T, v validators.Validator[T]) {
+	t.Helper()
+
+	r := rand.New(rand.NewSource(1))
+	a := validtest.NewArbitrary(v)
+
+	for range 50 {
+		value, err := a.Valid(r)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		validtest.AssertValid(t, v, value)
+	}
+
+	invalid := a.Invalid(r)
+	if len(invalid) == 0 {
+		t.Errorf("expected invalid values")
+	}
+
+	for _, value := range invalid {
+		if v.Validate(value) == nil {
+			t.Errorf("expected %#v to fail", value)
+		}
+	}
+}
+
+func TestArbitraryString(t *testing.T) {
+	validators := []validators.StringValidator[string]{
+		validators.NewStringValidator[string]().NotEmpty().MaxLen(3),
+		validators.NewStringValidator[string]().Len(7),
+		validators.NewStringValidator[string]().MinLen(10).HasPrefix("id_").NotContains("z"),
+		validators.NewStringValidator[string]().HasSuffix(".go").ContainsExact("/", 2),
+		validators.NewStringValidator[string]().In("red", "green", "blue").NotEqualTo("red"),
+		validators.NewStringValidator[string]().ValidUUID(),
+		validators.NewStringValidator[string]().Matches(regexp.MustCompile(`^[a-z]{2,4}-\d+$`)),
+	}
+
+	for _, v := range validators {
+		checkArbitrary(t, v)
+	}
+}
+
+func TestArbitraryNumber(t *testing.T) {
+	checkArbitrary(t, validators.NewNumberValidator[int]().GT(10).LTE(20).NotEqualTo(15))
+	checkArbitrary(t, validators.NewNumberValidator[int8]().GTE(math.MinInt8).Negative().NonZero())
+	checkArbitrary(t, validators.NewNumberValidator[uint16]().In(1, 2, 3))
+	checkArbitrary(t, validators.NewNumberValidator[float32]().GT(1.5).LT(2))
+	checkArbitrary(t, validators.NewNumberValidator[float64]().Positive().NotIn(0))
+}
+
+type Order struct {
+	ID    string
+	Items []Item
+	Note  *string
+	Extra map[string]int
+}
+
+type Item struct {
+	SKU      string
+	Quantity int
+}
+
+func TestArbitraryStruct(t *testing.T) {
+	checkArbitrary(t, validators.NewPointerValidator(
+		validators.MustStructValidator[Order](validators.StructShape{
+			"ID": validators.NewStringValidator[string]().ValidUUID(),
+			"Items": validators.NewSliceValidator[[]Item](
+				validators.MustStructValidator[Item](validators.StructShape{
+					"SKU":      validators.NewStringValidator[string]().Len(8),
+					"Quantity": validators.NewNumberValidator[int]().GT(0).LTE(100),
+				}),
+			).NotEmpty().MaxLen(3),
+			"Note": validators.NewPointerValidator(
+				validators.NewStringValidator[string]().MaxLen(140),
+			),
+			"Extra": validators.NewMapValidator[string, int]().HasKey("priority"),
+		}),
+	).NotNil())
+}
+
+type Shipment struct {
+	*Item
+	Destination *Destination
+}
+
+type Destination struct {
+	Country string
+	Postal  struct {
+		Code string
+	}
+}
+
+func TestArbitraryStructPaths(t *testing.T) {
+	checkArbitrary(t, validators.MustStructValidator[Shipment](validators.StructShape{
+		"SKU":                     validators.NewStringValidator[string]().Len(8),
+		"Destination":             validators.NewPointerValidator(validators.MustStructValidator[Destination](nil)).NotNil(),
+		"Destination.Country":     validators.NewStringValidator[string]().In("FR", "IT"),
+		"Destination.Postal.Code": validators.NewStringValidator[string]().Len(5),
+	}))
+}
+
+func TestArbitraryShrink(t *testing.T) {
+	a := validtest.NewArbitrary(validators.NewStringValidator[string]().MinLen(3).HasPrefix("x"))
+
+	got := a.Shrink("xyzzy-and-more", func(s string) bool { return strings.Contains(s, "z") })
+	if got != "xaz" {
+		t.Errorf("expected shrunk value xaz, got %q", got)
+	}
+
+	n := validtest.NewArbitrary(validators.NewNumberValidator[int]().GT(100))
+	if got := n.Shrink(5001, func(n int) bool { return n%2 == 1 }); got != 101 {
+		t.Errorf("expected shrunk value 101, got %d", got)
+	}
+
+	s := validtest.NewArbitrary(validators.NewSliceValidator[[]int](
+		validators.NewNumberValidator[int]().Positive(),
+	).MinLen(1))
+	if got := s.Shrink([]int{4, 99, 7, 1000}, func(s []int) bool { return slicesSum(s) > 50 }); len(got) != 1 || got[0] != 51 {
+		t.Errorf("expected shrunk value [51], got %v", got)
+	}
+}
+
+func slicesSum(s []int) int {
+	sum := 0
+	for _, n := range s {
+		sum += n
+	}
+
+	return sum
+}
+
+func TestArbitraryCheck(t *testing.T) {
+	a := validtest.NewArbitrary(validators.NewStringValidator[string]().MinLen(2).MaxLen(20))
+	r := rand.New(rand.NewSource(1))
+
+	a.Check(t, r, 100, func(s string) bool { return len(s) >= 2 })
+
+	rec := &recorder{TB: t}
+	if a.Check(rec, r, 100, func(s string) bool { return len(s) < 5 }) || len(rec.errs) != 1 {
+		t.Fatalf("expected a failing property to be reported, got %v", rec.errs)
+	}
+
+	if !strings.Contains(rec.errs[0], `"aaaaa"`) {
+		t.Errorf("expected failure to report the shrunk value, got %s", rec.errs[0])
+	}
+}
+
+func TestArbitraryQuickConfig(t *testing.T) {
+	v := validators.NewNumberValidator[int]().GTE(0).LT(1000)
+	a := validtest.NewArbitrary(v)
+
+	err := quick.Check(func(n int) bool { return n >= 0 && n < 1000 }, a.QuickConfig(rand.New(rand.NewSource(1))))
+	if err != nil {
+		t.Error(err)
+	}
+}
+
+type Slug string
+
+func FuzzArbitrarySeed(f *testing.F) {
+	v := validators.NewStringValidator[Slug]().MinLen(3).MaxLen(8)
+	validtest.NewArbitrary(v).Seed(f, rand.New(rand.NewSource(1)), 10)
+
+	f.Fuzz(func(t *testing.T, s string) {
+		err := v.Validate(Slug(s))
+		if ok := len(s) >= 3 && len(s) <= 8; ok != (err == nil) {
+			t.Errorf("unexpected result for %q: %v", s, err)
+		}
+	})
+}
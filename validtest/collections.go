@@ -0,0 +1,494 @@
+package validtest
+
+import (
+	"reflect"
+	"slices"
+	"strings"
+
+	"github.com/bitcrshr/valid/validators"
+)
+
+// lenConstraints collects the length rules shared by slices and maps. A
+// negative max means the length is unbounded.
+func lenConstraints(rules []validators.Rule) (lo, hi int) {
+	hi = -1
+	for _, r := range rules {
+		switch r.Name {
+		case "empty":
+			hi = 0
+		case "notEmpty":
+			lo = max(lo, 1)
+		case "len":
+			lo, hi = intParam(r, 0), intParam(r, 0)
+		case "minLen":
+			lo = max(lo, intParam(r, 0))
+		case "maxLen":
+			if l := intParam(r, 0); hi < 0 || l < hi {
+				hi = l
+			}
+		}
+	}
+
+	return lo, hi
+}
+
+func (g *generator) slice(t reflect.Type, v validators.AnyValidator, rules []validators.Rule) (reflect.Value, bool) {
+	lo, hi := lenConstraints(rules)
+	if hi < 0 {
+		hi = lo + 5
+	}
+
+	if lo > hi {
+		return reflect.Value{}, false
+	}
+
+	// Every element has to pass the element validator as well as the
+	// validators of AllSatisfy rules, while at least one has to pass those of
+	// AnySatisfy rules.
+	elem := allOf{elemOf(v)}
+	var anyOf []validators.AnyValidator
+	for _, r := range rules {
+		switch r.Name {
+		case "allSatisfy":
+			elem = append(elem, r.Params[0].(validators.AnyValidator))
+		case "anySatisfy":
+			anyOf = append(anyOf, r.Params[0].(validators.AnyValidator))
+		}
+	}
+
+	n := max(lo+g.r.Intn(hi-lo+1), min(len(anyOf), hi))
+
+	s := reflect.MakeSlice(t, n, n)
+	for i := range n {
+		ev := validators.AnyValidator(elem)
+		if i < len(anyOf) {
+			ev = append(slices.Clone(elem), anyOf[i])
+		}
+
+		el, err := g.valid(t.Elem(), ev)
+		if err != nil {
+			return reflect.Value{}, false
+		}
+
+		s.Index(i).Set(el)
+	}
+
+	return s, true
+}
+
+func (g *generator) invalidSlice(t reflect.Type, v validators.AnyValidator, base reflect.Value) []reflect.Value {
+	elem := elemOf(v)
+
+	// resize returns base grown with valid elements or truncated to n.
+	resize := func(n int) (reflect.Value, bool) {
+		if n < 0 {
+			return reflect.Value{}, false
+		}
+
+		s := reflect.MakeSlice(t, n, n)
+		reflect.Copy(s, base)
+		for i := base.Len(); i < n; i++ {
+			el, err := g.valid(t.Elem(), elem)
+			if err != nil {
+				return reflect.Value{}, false
+			}
+
+			s.Index(i).Set(el)
+		}
+
+		return s, true
+	}
+
+	var candidates []reflect.Value
+	add := func(s reflect.Value, ok bool) {
+		if ok {
+			candidates = append(candidates, s)
+		}
+	}
+
+	for _, r := range rulesOf(v) {
+		switch r.Name {
+		case "empty":
+			add(resize(1))
+		case "notEmpty":
+			add(resize(0))
+		case "len":
+			add(resize(intParam(r, 0) + 1))
+			add(resize(intParam(r, 0) - 1))
+		case "minLen":
+			add(resize(intParam(r, 0) - 1))
+		case "maxLen":
+			add(resize(intParam(r, 0) + 1))
+		}
+	}
+
+	// Replace the first element with each invalid element.
+	for _, el := range g.invalid(t.Elem(), elem) {
+		s, ok := resize(max(base.Len(), 1))
+		if !ok {
+			break
+		}
+
+		s.Index(0).Set(el)
+		candidates = append(candidates, s)
+	}
+
+	return candidates
+}
+
+func (g *generator) shrinkSlice(t reflect.Type, v validators.AnyValidator, val reflect.Value) []reflect.Value {
+	n := val.Len()
+	if n == 0 {
+		return nil
+	}
+
+	candidates := []reflect.Value{reflect.MakeSlice(t, 0, 0)}
+	if n > 1 {
+		candidates = append(candidates, val.Slice(0, n/2), val.Slice(n/2, n))
+	}
+
+	for i := range min(n, 32) {
+		s := reflect.MakeSlice(t, 0, n-1)
+		s = reflect.AppendSlice(s, val.Slice(0, i))
+		s = reflect.AppendSlice(s, val.Slice(i+1, n))
+		candidates = append(candidates, s)
+	}
+
+	elem := elemOf(v)
+	for i := range min(n, 32) {
+		for _, el := range g.shrinks(t.Elem(), elem, val.Index(i)) {
+			s := reflect.MakeSlice(t, n, n)
+			reflect.Copy(s, val)
+			s.Index(i).Set(el)
+			candidates = append(candidates, s)
+		}
+	}
+
+	return candidates
+}
+
+func (g *generator) pointer(t reflect.Type, v validators.AnyValidator, rules []validators.Rule) (reflect.Value, bool) {
+	isNil := g.r.Intn(5) == 0
+	for _, r := range rules {
+		switch r.Name {
+		case "nil":
+			isNil = true
+		case "notNil":
+			isNil = false
+		}
+	}
+
+	if isNil {
+		return reflect.Zero(t), true
+	}
+
+	el, err := g.valid(t.Elem(), elemOf(v))
+	if err != nil {
+		return reflect.Value{}, false
+	}
+
+	p := reflect.New(t.Elem())
+	p.Elem().Set(el)
+
+	return p, true
+}
+
+func (g *generator) invalidPointer(t reflect.Type, v validators.AnyValidator, base reflect.Value) []reflect.Value {
+	elem := elemOf(v)
+
+	var candidates []reflect.Value
+	for _, r := range rulesOf(v) {
+		switch r.Name {
+		case "nil":
+			if el, err := g.valid(t.Elem(), elem); err == nil {
+				p := reflect.New(t.Elem())
+				p.Elem().Set(el)
+				candidates = append(candidates, p)
+			}
+		case "notNil":
+			candidates = append(candidates, reflect.Zero(t))
+		}
+	}
+
+	for _, el := range g.invalid(t.Elem(), elem) {
+		p := reflect.New(t.Elem())
+		p.Elem().Set(el)
+		candidates = append(candidates, p)
+	}
+
+	return candidates
+}
+
+func (g *generator) shrinkPointer(t reflect.Type, v validators.AnyValidator, val reflect.Value) []reflect.Value {
+	if val.IsNil() {
+		return nil
+	}
+
+	candidates := []reflect.Value{reflect.Zero(t)}
+	for _, el := range g.shrinks(t.Elem(), elemOf(v), val.Elem()) {
+		p := reflect.New(t.Elem())
+		p.Elem().Set(el)
+		candidates = append(candidates, p)
+	}
+
+	return candidates
+}
+
+func (g *generator) mapping(t reflect.Type, rules []validators.Rule) (reflect.Value, bool) {
+	lo, hi := lenConstraints(rules)
+	if hi < 0 {
+		hi = lo + 5
+	}
+
+	m := reflect.MakeMap(t)
+	put := func(k reflect.Value) bool {
+		el, err := g.valid(t.Elem(), nil)
+		if err != nil {
+			return false
+		}
+
+		m.SetMapIndex(k, el)
+
+		return true
+	}
+
+	for _, r := range rules {
+		switch r.Name {
+		case "hasKey":
+			if !put(reflect.ValueOf(r.Params[0])) {
+				return reflect.Value{}, false
+			}
+		case "hasKeyIn":
+			if len(r.Params) == 0 {
+				return reflect.Value{}, false
+			}
+
+			if !put(reflect.ValueOf(r.Params[g.r.Intn(len(r.Params))])) {
+				return reflect.Value{}, false
+			}
+		}
+	}
+
+	n := lo + g.r.Intn(max(hi-lo+1, 1))
+	for range maxAttempts {
+		if m.Len() >= n {
+			break
+		}
+
+		k, err := g.valid(t.Key(), nil)
+		if err != nil || !put(k) {
+			return reflect.Value{}, false
+		}
+	}
+
+	return m, true
+}
+
+func (g *generator) invalidMap(t reflect.Type, rules []validators.Rule, base reflect.Value) []reflect.Value {
+	var candidates []reflect.Value
+
+	with := func(keys ...any) {
+		m := reflect.MakeMap(t)
+		for _, k := range base.MapKeys() {
+			m.SetMapIndex(k, base.MapIndex(k))
+		}
+
+		for _, k := range keys {
+			el, err := g.valid(t.Elem(), nil)
+			if err != nil {
+				return
+			}
+
+			m.SetMapIndex(reflect.ValueOf(k), el)
+		}
+
+		candidates = append(candidates, m)
+	}
+
+	without := func(keys ...any) {
+		m := reflect.MakeMap(t)
+		for _, k := range base.MapKeys() {
+			if !slices.Contains(keys, k.Interface()) {
+				m.SetMapIndex(k, base.MapIndex(k))
+			}
+		}
+
+		candidates = append(candidates, m)
+	}
+
+	for _, r := range rules {
+		switch r.Name {
+		case "empty":
+			if base.Len() == 0 {
+				if k, err := g.valid(t.Key(), nil); err == nil {
+					with(k.Interface())
+				}
+			}
+		case "notEmpty":
+			candidates = append(candidates, reflect.MakeMap(t))
+		case "hasKey", "hasKeyIn":
+			without(r.Params...)
+		case "notHasKey", "notHasKeyIn":
+			if len(r.Params) > 0 {
+				with(r.Params[0])
+			}
+		}
+	}
+
+	return candidates
+}
+
+func shrinkMap(t reflect.Type, val reflect.Value) []reflect.Value {
+	var candidates []reflect.Value
+	for _, k := range val.MapKeys() {
+		m := reflect.MakeMap(t)
+		for _, other := range val.MapKeys() {
+			if other.Interface() != k.Interface() {
+				m.SetMapIndex(other, val.MapIndex(other))
+			}
+		}
+
+		candidates = append(candidates, m)
+	}
+
+	return candidates
+}
+
+func (g *generator) structure(t reflect.Type, v validators.AnyValidator) (reflect.Value, bool) {
+	fields := fieldValidators(t, shapeOf(v))
+
+	s := reflect.New(t).Elem()
+	for i := range t.NumField() {
+		f := t.Field(i)
+		if !f.IsExported() {
+			continue
+		}
+
+		val, err := g.valid(f.Type, fields[i])
+		if err != nil {
+			return reflect.Value{}, false
+		}
+
+		s.Field(i).Set(val)
+	}
+
+	return s, true
+}
+
+func (g *generator) invalidStruct(t reflect.Type, v validators.AnyValidator, base reflect.Value) []reflect.Value {
+	var candidates []reflect.Value
+	for _, r := range rulesOf(v) {
+		switch r.Name {
+		case "notZero":
+			candidates = append(candidates, reflect.Zero(t))
+		case "zero":
+			candidates = append(candidates, base)
+		}
+	}
+
+	fields := fieldValidators(t, shapeOf(v))
+	for i := range t.NumField() {
+		if !t.Field(i).IsExported() || fields[i] == nil {
+			continue
+		}
+
+		for _, val := range g.invalid(t.Field(i).Type, fields[i]) {
+			s := reflect.New(t).Elem()
+			s.Set(base)
+			s.Field(i).Set(val)
+			candidates = append(candidates, s)
+		}
+	}
+
+	return candidates
+}
+
+func (g *generator) shrinkStruct(t reflect.Type, v validators.AnyValidator, val reflect.Value) []reflect.Value {
+	fields := fieldValidators(t, shapeOf(v))
+
+	var candidates []reflect.Value
+	for i := range t.NumField() {
+		f := t.Field(i)
+		if !f.IsExported() {
+			continue
+		}
+
+		for _, fv := range g.shrinks(f.Type, fields[i], val.Field(i)) {
+			s := reflect.New(t).Elem()
+			s.Set(val)
+			s.Field(i).Set(fv)
+			candidates = append(candidates, s)
+		}
+	}
+
+	return candidates
+}
+
+// fieldValidators returns the validator for each direct field of t. Besides
+// the entry of shape for the field itself, it takes into account entries with
+// paths through the field, like "Address.City", and entries for fields
+// promoted from it if it is embedded.
+func fieldValidators(t reflect.Type, shape validators.StructShape) []validators.AnyValidator {
+	fields := make([]validators.AnyValidator, t.NumField())
+	nested := make([]subShape, t.NumField())
+	for key, fv := range shape {
+		name, rest, dotted := strings.Cut(key, ".")
+
+		f, ok := t.FieldByName(name)
+		if !ok {
+			continue
+		}
+
+		i := f.Index[0]
+		if len(f.Index) > 1 {
+			// Promoted from the embedded field i.
+			rest, dotted = key, true
+		}
+
+		if !dotted {
+			fields[i] = fv
+			continue
+		}
+
+		if nested[i] == nil {
+			nested[i] = subShape{}
+		}
+
+		nested[i][rest] = fv
+	}
+
+	for i, s := range nested {
+		if s != nil {
+			fields[i] = allOf{fields[i], s}
+		}
+	}
+
+	return fields
+}
+
+// subShape validates the entries of a shape whose paths lead through a
+// field, against the struct in that field or the one it points to.
+type subShape validators.StructShape
+
+func (s subShape) ValidateAny(value any) error {
+	rv := reflect.ValueOf(value)
+	for rv.Kind() == reflect.Pointer {
+		if rv.IsNil() {
+			return nil
+		}
+
+		rv = rv.Elem()
+	}
+
+	return validators.MustStructValidator[any](validators.StructShape(s)).ValidateAny(rv.Interface())
+}
+
+func (s subShape) Shape() validators.StructShape {
+	return validators.StructShape(s)
+}
+
+// ElemValidator makes the entries apply to the struct when the field is a
+// pointer.
+func (s subShape) ElemValidator() validators.AnyValidator {
+	return s
+}
@@ -0,0 +1,344 @@
+package validtest
+
+import (
+	"math"
+	"reflect"
+	"slices"
+
+	"github.com/bitcrshr/valid/validators"
+)
+
+// number is the domain numeric constraints are computed in: signed integers
+// as int64, unsigned integers as uint64 and floats as float64.
+type number interface {
+	int64 | uint64 | float64
+}
+
+// numRange is what generation understands of the rules of a number
+// validator. When oneOf is non-nil, values must also be one of its elements.
+type numRange[N number] struct {
+	lo, hi N
+	oneOf  []N
+	empty  bool
+}
+
+// arith abstracts over the operations that differ between the domains.
+type arith[N number] struct {
+	get      func(reflect.Value) N
+	set      func(reflect.Value, N)
+	next     func(N) N
+	prev     func(N) N
+	min, max N
+	// smallMin is the lower bound of the small numbers generation favors.
+	smallMin N
+}
+
+func numberConstraints[N number](rules []validators.Rule, a arith[N]) numRange[N] {
+	c := numRange[N]{lo: a.min, hi: a.max}
+
+	param := func(r validators.Rule, i int) N {
+		return a.get(reflect.ValueOf(r.Params[i]))
+	}
+
+	for _, r := range rules {
+		switch r.Name {
+		case "positive":
+			c.lo = max(c.lo, 0)
+		case "negative":
+			c.hi = min(c.hi, 0)
+		case "zero":
+			c.oneOf = []N{0}
+		case "lt":
+			if p := param(r, 0); p <= a.min {
+				c.empty = true
+			} else {
+				c.hi = min(c.hi, a.prev(p))
+			}
+		case "lte":
+			c.hi = min(c.hi, param(r, 0))
+		case "gt":
+			if p := param(r, 0); p >= a.max {
+				c.empty = true
+			} else {
+				c.lo = max(c.lo, a.next(p))
+			}
+		case "gte":
+			c.lo = max(c.lo, param(r, 0))
+		case "equalTo":
+			c.oneOf = []N{param(r, 0)}
+		case "in":
+			if c.oneOf == nil {
+				c.oneOf = make([]N, len(r.Params))
+				for i := range r.Params {
+					c.oneOf[i] = param(r, i)
+				}
+			}
+		}
+	}
+
+	if c.lo > c.hi {
+		c.empty = true
+	}
+
+	return c
+}
+
+func (g *generator) number(t reflect.Type, rules []validators.Rule) (reflect.Value, bool) {
+	switch t.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return genNumber(g, t, rules, intArith(t), func(lo, hi int64) int64 {
+			span := uint64(hi - lo)
+			if span == math.MaxUint64 {
+				return int64(g.r.Uint64())
+			}
+
+			return lo + int64(g.r.Uint64()%(span+1))
+		})
+	case reflect.Float32, reflect.Float64:
+		return genNumber(g, t, rules, floatArith(t), func(lo, hi float64) float64 {
+			if math.IsInf(hi-lo, 0) {
+				return max(lo, min(hi, g.r.NormFloat64()*1e6))
+			}
+
+			return lo + g.r.Float64()*(hi-lo)
+		})
+	default:
+		return genNumber(g, t, rules, uintArith(t), func(lo, hi uint64) uint64 {
+			span := hi - lo
+			if span == math.MaxUint64 {
+				return g.r.Uint64()
+			}
+
+			return lo + g.r.Uint64()%(span+1)
+		})
+	}
+}
+
+// genNumber picks a value within the constraints, favoring the bounds and
+// small numbers since that is where bugs tend to hide.
+func genNumber[N number](g *generator, t reflect.Type, rules []validators.Rule, a arith[N], uniform func(lo, hi N) N) (reflect.Value, bool) {
+	c := numberConstraints(rules, a)
+	if c.empty {
+		return reflect.Value{}, false
+	}
+
+	var n N
+	switch {
+	case c.oneOf != nil:
+		if len(c.oneOf) == 0 {
+			return reflect.Value{}, false
+		}
+
+		n = c.oneOf[g.r.Intn(len(c.oneOf))]
+	default:
+		switch g.r.Intn(4) {
+		case 0:
+			n = c.lo
+		case 1:
+			n = c.hi
+		case 2:
+			if lo, hi := max(c.lo, a.smallMin), min(c.hi, 100); lo <= hi {
+				n = uniform(lo, hi)
+				break
+			}
+
+			n = uniform(c.lo, c.hi)
+		default:
+			n = uniform(c.lo, c.hi)
+		}
+	}
+
+	val := reflect.New(t).Elem()
+	a.set(val, n)
+
+	return val, true
+}
+
+func (g *generator) invalidNumber(t reflect.Type, rules []validators.Rule) []reflect.Value {
+	switch t.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return invalidNumber(t, rules, intArith(t))
+	case reflect.Float32, reflect.Float64:
+		return invalidNumber(t, rules, floatArith(t))
+	default:
+		return invalidNumber(t, rules, uintArith(t))
+	}
+}
+
+func invalidNumber[N number](t reflect.Type, rules []validators.Rule, a arith[N]) []reflect.Value {
+	var candidates []N
+
+	// around adds the values just outside of p, if they are representable.
+	around := func(p N) {
+		if p > a.min {
+			candidates = append(candidates, a.prev(p))
+		}
+
+		if p < a.max {
+			candidates = append(candidates, a.next(p))
+		}
+	}
+
+	for _, r := range rules {
+		param := func(i int) N {
+			return a.get(reflect.ValueOf(r.Params[i]))
+		}
+
+		switch r.Name {
+		case "positive":
+			if a.min < 0 {
+				candidates = append(candidates, a.prev(0))
+			}
+		case "negative":
+			candidates = append(candidates, a.next(0))
+		case "zero":
+			around(0)
+		case "nonZero", "notEqualTo":
+			var p N
+			if r.Name == "notEqualTo" {
+				p = param(0)
+			}
+
+			candidates = append(candidates, p)
+		case "lt", "gt":
+			candidates = append(candidates, param(0))
+		case "lte":
+			if p := param(0); p < a.max {
+				candidates = append(candidates, a.next(p))
+			}
+		case "gte":
+			if p := param(0); p > a.min {
+				candidates = append(candidates, a.prev(p))
+			}
+		case "equalTo":
+			around(param(0))
+		case "in":
+			haystack := make([]N, len(r.Params))
+			for i := range r.Params {
+				haystack[i] = param(i)
+			}
+
+			if len(haystack) == 0 {
+				candidates = append(candidates, 0)
+				continue
+			}
+
+			around(slices.Min(haystack))
+			around(slices.Max(haystack))
+		case "notIn":
+			if len(r.Params) > 0 {
+				candidates = append(candidates, param(0))
+			}
+		}
+	}
+
+	vals := make([]reflect.Value, len(candidates))
+	for i, c := range candidates {
+		vals[i] = reflect.New(t).Elem()
+		a.set(vals[i], c)
+	}
+
+	return vals
+}
+
+func shrinkNumber(t reflect.Type, val reflect.Value) []reflect.Value {
+	switch t.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return shrinkTowardZero(t, val, intArith(t))
+	case reflect.Float32, reflect.Float64:
+		f := val.Float()
+		vals := shrinkTowardZero(t, val, floatArith(t))
+		if trunc := math.Trunc(f); trunc != f {
+			v := reflect.New(t).Elem()
+			v.SetFloat(trunc)
+			vals = append(vals, v)
+		}
+
+		return vals
+	default:
+		return shrinkTowardZero(t, val, uintArith(t))
+	}
+}
+
+func shrinkTowardZero[N number](t reflect.Type, val reflect.Value, a arith[N]) []reflect.Value {
+	n := a.get(val)
+	if n == 0 {
+		return nil
+	}
+
+	// Move toward zero in ever smaller steps, so that values close to a
+	// lower bound can still be reached.
+	candidates := []N{0}
+	for i, d := 0, n/2; i < 64 && d != 0; i, d = i+1, d/2 {
+		candidates = append(candidates, n-d)
+	}
+
+	switch {
+	case n > 1:
+		candidates = append(candidates, a.prev(n), a.prev(a.prev(n)))
+	case n > 0:
+		candidates = append(candidates, a.prev(n))
+	default:
+		candidates = append(candidates, a.next(n), a.next(a.next(n)))
+	}
+
+	var vals []reflect.Value
+	for _, c := range candidates {
+		if c == n {
+			continue
+		}
+
+		v := reflect.New(t).Elem()
+		a.set(v, c)
+		vals = append(vals, v)
+	}
+
+	return vals
+}
+
+func intArith(t reflect.Type) arith[int64] {
+	bits := t.Bits()
+
+	return arith[int64]{
+		get:      reflect.Value.Int,
+		set:      reflect.Value.SetInt,
+		next:     func(n int64) int64 { return n + 1 },
+		prev:     func(n int64) int64 { return n - 1 },
+		min:      math.MinInt64 >> (64 - bits),
+		max:      math.MaxInt64 >> (64 - bits),
+		smallMin: -100,
+	}
+}
+
+func uintArith(t reflect.Type) arith[uint64] {
+	bits := t.Bits()
+
+	return arith[uint64]{
+		get:  reflect.Value.Uint,
+		set:  reflect.Value.SetUint,
+		next: func(n uint64) uint64 { return n + 1 },
+		prev: func(n uint64) uint64 { return n - 1 },
+		min:  0,
+		max:  math.MaxUint64 >> (64 - bits),
+	}
+}
+
+func floatArith(t reflect.Type) arith[float64] {
+	a := arith[float64]{
+		get:      reflect.Value.Float,
+		set:      reflect.Value.SetFloat,
+		next:     func(n float64) float64 { return math.Nextafter(n, math.Inf(1)) },
+		prev:     func(n float64) float64 { return math.Nextafter(n, math.Inf(-1)) },
+		min:      -math.MaxFloat64,
+		max:      math.MaxFloat64,
+		smallMin: -100,
+	}
+
+	if t.Kind() == reflect.Float32 {
+		a.next = func(n float64) float64 { return float64(math.Nextafter32(float32(n), float32(math.Inf(1)))) }
+		a.prev = func(n float64) float64 { return float64(math.Nextafter32(float32(n), float32(math.Inf(-1)))) }
+		a.min, a.max = -math.MaxFloat32, math.MaxFloat32
+	}
+
+	return a
+}
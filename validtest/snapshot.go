@@ -0,0 +1,58 @@
+package validtest
+
+import (
+	"encoding/json"
+	"errors"
+	"io/fs"
+	"os"
+	"testing"
+
+	"github.com/bitcrshr/valid/validators"
+)
+
+// AssertCompatible compares v with the snapshot of its description in the
+// JSON file at path, see validators.Compare, and reports a test error if any
+// change may make values that passed the snapshot fail, e.g. because a rule
+// was added. Other changes are logged. If the file does not exist, it is
+// written from v and the test passes; remove it to accept changes.
+func AssertCompatible(t testing.TB, v validators.AnyValidator, path string) bool {
+	t.Helper()
+
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		data, err = json.MarshalIndent(validators.Describe(v), "", "\t")
+		if err == nil {
+			err = os.WriteFile(path, append(data, '\n'), 0o644)
+		}
+
+		if err != nil {
+			t.Errorf("writing snapshot %s: %v", path, err)
+			return false
+		}
+
+		t.Logf("wrote snapshot %s", path)
+
+		return true
+	} else if err != nil {
+		t.Errorf("reading snapshot %s: %v", path, err)
+		return false
+	}
+
+	var old validators.Description
+	if err := json.Unmarshal(data, &old); err != nil {
+		t.Errorf("decoding snapshot %s: %v", path, err)
+		return false
+	}
+
+	changes := validators.Compare(old, validators.Describe(v))
+	if changes.Breaking() {
+		t.Errorf("validator is not compatible with snapshot %s:\n%s", path, changes)
+		return false
+	}
+
+	if len(changes) > 0 {
+		t.Logf("validator changed compatibly since snapshot %s, remove it to update:\n%s", path, changes)
+	}
+
+	return true
+}
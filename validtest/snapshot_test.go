@@ -0,0 +1,50 @@
+package validtest_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/bitcrshr/valid/validators"
+	"github.com/bitcrshr/valid/validtest"
+)
+
+func TestAssertCompatible(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "user.json")
+
+	if !validtest.AssertCompatible(t, userValidator(), path) {
+		t.Fatal("expected the missing snapshot to be written")
+	}
+
+	if _, err := os.Stat(path); err != nil {
+		t.Fatalf("expected the snapshot to exist, got %v", err)
+	}
+
+	validtest.AssertCompatible(t, userValidator(), path)
+
+	looser := validators.MustStructValidator[User](validators.StructShape{
+		"Name": validators.NewStringValidator[string]().MinLen(1),
+	})
+	validtest.AssertCompatible(t, looser, path)
+
+	// The bounds of Name are those of the snapshot.
+	redundant := validators.MustStructValidator[User](validators.StructShape{
+		"Name": validators.NewStringValidator[string]().MinLen(1).MinLen(2),
+	})
+	validtest.AssertCompatible(t, redundant, path)
+
+	stricter := validators.MustStructValidator[User](validators.StructShape{
+		"Name": validators.NewStringValidator[string]().MinLen(3),
+		"Tags": validators.NewSliceValidator[[]string](
+			validators.NewStringValidator[string]().NotEmpty(),
+		),
+		"Address": validators.MustStructValidator[Address](validators.StructShape{
+			"City": validators.NewStringValidator[string]().NotEmpty(),
+		}),
+	})
+
+	r := &recorder{TB: t}
+	if validtest.AssertCompatible(r, stricter, path) || len(r.errs) != 1 {
+		t.Errorf("expected a higher min len to be reported, got %v", r.errs)
+	}
+}
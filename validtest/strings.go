@@ -0,0 +1,325 @@
+package validtest
+
+import (
+	"reflect"
+	"regexp"
+	"regexp/syntax"
+	"slices"
+	"strings"
+
+	"github.com/bitcrshr/valid/validators"
+	"github.com/google/uuid"
+)
+
+const alphabet = "abcdefghijklmnopqrstuvwxyz0123456789"
+
+// strConstraints is what generation understands of the rules of a string
+// validator. A negative max means the length is unbounded.
+type strConstraints struct {
+	min, max int
+	oneOf    []string
+	uuid     bool
+	regex    *regexp.Regexp
+	prefix   string
+	suffix   string
+	needles  []string
+}
+
+func stringConstraints(rules []validators.Rule) strConstraints {
+	c := strConstraints{max: -1}
+
+	for _, r := range rules {
+		switch r.Name {
+		case "empty":
+			c.max = 0
+		case "notEmpty":
+			c.min = max(c.min, 1)
+		case "len":
+			c.min, c.max = intParam(r, 0), intParam(r, 0)
+		case "minLen":
+			c.min = max(c.min, intParam(r, 0))
+		case "maxLen":
+			if l := intParam(r, 0); c.max < 0 || l < c.max {
+				c.max = l
+			}
+		case "equalTo":
+			c.oneOf = []string{strParam(r, 0)}
+		case "in":
+			if c.oneOf == nil {
+				for i := range r.Params {
+					c.oneOf = append(c.oneOf, strParam(r, i))
+				}
+			}
+		case "uuid":
+			c.uuid = true
+		case "matches":
+			c.regex, _ = r.Params[0].(*regexp.Regexp)
+		case "hasPrefix":
+			if p := strParam(r, 0); len(p) > len(c.prefix) {
+				c.prefix = p
+			}
+		case "hasSuffix":
+			if s := strParam(r, 0); len(s) > len(c.suffix) {
+				c.suffix = s
+			}
+		case "contains":
+			c.needles = append(c.needles, strParam(r, 0))
+		case "containsAtLeast", "containsExact":
+			for range intParam(r, 1) {
+				c.needles = append(c.needles, strParam(r, 0))
+			}
+		}
+	}
+
+	return c
+}
+
+func (g *generator) str(t reflect.Type, rules []validators.Rule) (reflect.Value, bool) {
+	c := stringConstraints(rules)
+
+	var s string
+	switch {
+	case c.oneOf != nil:
+		if len(c.oneOf) == 0 {
+			return reflect.Value{}, false
+		}
+
+		s = c.oneOf[g.r.Intn(len(c.oneOf))]
+	case c.uuid:
+		id, err := uuid.NewRandomFromReader(g.r)
+		if err != nil {
+			return reflect.Value{}, false
+		}
+
+		s = id.String()
+	case c.regex != nil:
+		re, err := syntax.Parse(c.regex.String(), syntax.Perl)
+		if err != nil {
+			return reflect.Value{}, false
+		}
+
+		var b strings.Builder
+		g.fromRegex(&b, re.Simplify())
+		s = b.String()
+	default:
+		required := c.prefix + strings.Join(c.needles, "") + c.suffix
+
+		lo := max(c.min, len(required))
+		hi := c.max
+		if hi < 0 {
+			hi = lo + 16
+		}
+
+		if lo > hi {
+			return reflect.Value{}, false
+		}
+
+		filler := g.randomString(lo + g.r.Intn(hi-lo+1) - len(required))
+		s = c.prefix + filler + strings.Join(c.needles, "") + c.suffix
+	}
+
+	return reflect.ValueOf(s).Convert(t), true
+}
+
+func (g *generator) randomString(n int) string {
+	b := make([]byte, n)
+	for i := range b {
+		b[i] = alphabet[g.r.Intn(len(alphabet))]
+	}
+
+	return string(b)
+}
+
+// fromRegex writes a random string matching re to b.
+func (g *generator) fromRegex(b *strings.Builder, re *syntax.Regexp) {
+	repeat := func(min, max int) {
+		if max < 0 {
+			max = min + 3
+		}
+
+		for range min + g.r.Intn(max-min+1) {
+			for _, sub := range re.Sub {
+				g.fromRegex(b, sub)
+			}
+		}
+	}
+
+	switch re.Op {
+	case syntax.OpLiteral:
+		b.WriteString(string(re.Rune))
+	case syntax.OpCharClass:
+		// Prefer the first printable range so that generated strings stay
+		// readable, e.g. [^0-9] yields letters rather than control runes.
+		ranges := re.Rune
+		for i := 0; i+1 < len(re.Rune); i += 2 {
+			if re.Rune[i+1] >= ' ' {
+				ranges = re.Rune[i:]
+				break
+			}
+		}
+
+		if len(ranges) >= 2 {
+			lo, hi := max(ranges[0], ' '), ranges[1]
+			if lo > hi {
+				lo = ranges[0]
+			}
+
+			b.WriteRune(lo + rune(g.r.Intn(int(min(hi-lo, 25))+1)))
+		}
+	case syntax.OpAnyChar, syntax.OpAnyCharNotNL:
+		b.WriteByte(alphabet[g.r.Intn(len(alphabet))])
+	case syntax.OpCapture, syntax.OpConcat:
+		for _, sub := range re.Sub {
+			g.fromRegex(b, sub)
+		}
+	case syntax.OpStar:
+		repeat(0, 3)
+	case syntax.OpPlus:
+		repeat(1, 4)
+	case syntax.OpQuest:
+		repeat(0, 1)
+	case syntax.OpRepeat:
+		repeat(re.Min, re.Max)
+	case syntax.OpAlternate:
+		g.fromRegex(b, re.Sub[g.r.Intn(len(re.Sub))])
+	}
+}
+
+func (g *generator) invalidStr(t reflect.Type, rules []validators.Rule, base reflect.Value) []reflect.Value {
+	s := base.String()
+
+	var candidates []string
+	for _, r := range rules {
+		switch r.Name {
+		case "empty":
+			candidates = append(candidates, "x")
+		case "notEmpty":
+			candidates = append(candidates, "")
+		case "len":
+			l := intParam(r, 0)
+			candidates = append(candidates, resize(s, l+1))
+			if l > 0 {
+				candidates = append(candidates, resize(s, l-1))
+			}
+		case "minLen":
+			if l := intParam(r, 0); l > 0 {
+				candidates = append(candidates, resize(s, l-1))
+			}
+		case "maxLen":
+			candidates = append(candidates, resize(s, intParam(r, 0)+1))
+		case "equalTo":
+			candidates = append(candidates, strParam(r, 0)+"x")
+		case "notEqualTo":
+			candidates = append(candidates, strParam(r, 0))
+		case "hasPrefix":
+			candidates = append(candidates, strings.TrimPrefix(s, strParam(r, 0)))
+		case "notHasPrefix":
+			candidates = append(candidates, strParam(r, 0)+s)
+		case "hasSuffix":
+			candidates = append(candidates, strings.TrimSuffix(s, strParam(r, 0)))
+		case "notHasSuffix":
+			candidates = append(candidates, s+strParam(r, 0))
+		case "contains":
+			candidates = append(candidates, strings.ReplaceAll(s, strParam(r, 0), ""))
+		case "notContains":
+			candidates = append(candidates, s+strParam(r, 0))
+		case "containsAtLeast":
+			needle := strParam(r, 0)
+			if n := intParam(r, 1); n > 0 && needle != "" {
+				candidates = append(candidates, strings.ReplaceAll(s, needle, "")+strings.Repeat(needle, n-1))
+			}
+		case "containsAtMost", "containsExact":
+			needle := strParam(r, 0)
+			candidates = append(candidates, strings.ReplaceAll(s, needle, "")+strings.Repeat(needle, intParam(r, 1)+1))
+		case "in":
+			var longest string
+			for i := range r.Params {
+				if p := strParam(r, i); len(p) >= len(longest) {
+					longest = p
+				}
+			}
+
+			candidates = append(candidates, longest+"x")
+		case "notIn":
+			if len(r.Params) > 0 {
+				candidates = append(candidates, strParam(r, 0))
+			}
+		case "matches":
+			candidates = append(candidates, "", s+"\x00", g.randomString(8))
+		case "notMatches":
+			if re, ok := r.Params[0].(*regexp.Regexp); ok {
+				if parsed, err := syntax.Parse(re.String(), syntax.Perl); err == nil {
+					var b strings.Builder
+					g.fromRegex(&b, parsed.Simplify())
+					candidates = append(candidates, b.String())
+				}
+			}
+		case "uuid":
+			candidates = append(candidates, "")
+			if len(s) > 0 {
+				candidates = append(candidates, s[:len(s)-1]+"z")
+			}
+		}
+	}
+
+	vals := make([]reflect.Value, len(candidates))
+	for i, c := range candidates {
+		vals[i] = reflect.ValueOf(c).Convert(t)
+	}
+
+	return vals
+}
+
+func shrinkStr(t reflect.Type, val reflect.Value) []reflect.Value {
+	s := val.String()
+	if s == "" {
+		return nil
+	}
+
+	candidates := []string{"", s[:len(s)/2], s[len(s)/2:]}
+	for i := range min(len(s), 32) {
+		candidates = append(candidates, s[:i]+s[i+1:])
+	}
+
+	for i := range min(len(s), 32) {
+		if s[i] != 'a' {
+			candidates = append(candidates, s[:i]+"a"+s[i+1:])
+		}
+	}
+
+	candidates = slices.DeleteFunc(candidates, func(c string) bool { return c == s })
+
+	vals := make([]reflect.Value, len(candidates))
+	for i, c := range candidates {
+		vals[i] = reflect.ValueOf(c).Convert(t)
+	}
+
+	return vals
+}
+
+// resize truncates or pads s to exactly n bytes.
+func resize(s string, n int) string {
+	if len(s) >= n {
+		return s[:n]
+	}
+
+	return s + strings.Repeat("a", n-len(s))
+}
+
+func strParam(r validators.Rule, i int) string {
+	if i >= len(r.Params) {
+		return ""
+	}
+
+	return reflect.ValueOf(r.Params[i]).String()
+}
+
+func intParam(r validators.Rule, i int) int {
+	if i >= len(r.Params) {
+		return 0
+	}
+
+	n, _ := r.Params[i].(int)
+
+	return n
+}
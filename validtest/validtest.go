@@ -0,0 +1,170 @@
+// Package validtest provides helpers for testing validators and the values
+// they guard.
+package validtest
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/bitcrshr/valid/validators"
+)
+
+// Violation identifies a single rule violation by where it happened and which
+// rule reported it.
+type Violation struct {
+	Path string
+	Code string
+}
+
+func (v Violation) String() string {
+	if v.Path == "" {
+		return "<root>: " + v.Code
+	}
+
+	return v.Path + ": " + v.Code
+}
+
+// Violations returns the violations carried by err, sorted by path and code.
+func Violations(err error) []Violation {
+	errs := validators.AsErrors(err)
+
+	violations := make([]Violation, len(errs))
+	for i, e := range errs {
+		violations[i] = Violation{Path: e.Path, Code: e.Code}
+	}
+
+	slices.SortFunc(violations, compareViolations)
+
+	return violations
+}
+
+// AssertValid reports a test error if value does not pass v.
+func AssertValid[T any](t testing.TB, v validators.Validator[T], value T) bool {
+	t.Helper()
+
+	if err := v.Validate(value); err != nil {
+		t.Errorf("expected %#v to pass, but got:\n%s", value, render(validators.AsErrors(err)))
+		return false
+	}
+
+	return true
+}
+
+// AssertInvalid reports a test error unless validating value with v produces
+// a violation of the rule wantCode at wantPath. Use an empty wantPath for
+// violations of the root value.
+func AssertInvalid[T any](t testing.TB, v validators.Validator[T], value T, wantPath, wantCode string) bool {
+	t.Helper()
+
+	want := Violation{Path: wantPath, Code: wantCode}
+
+	err := v.Validate(value)
+	if err == nil {
+		t.Errorf("expected %#v to fail with %s, but it passed", value, want)
+		return false
+	}
+
+	if !slices.Contains(Violations(err), want) {
+		t.Errorf("expected %#v to fail with %s, but got:\n%s", value, want, render(validators.AsErrors(err)))
+		return false
+	}
+
+	return true
+}
+
+// Case is a named input for Cases. A Case with no Want must pass validation,
+// otherwise it must produce exactly the listed violations, in any order.
+type Case[T any] struct {
+	Name  string
+	Value T
+	Want  []Violation
+}
+
+// Cases is a table of inputs that are run against a single validator.
+type Cases[T any] []Case[T]
+
+// Run validates every case with v in its own subtest and reports a diff of
+// the expected and actual violations for each case that does not match.
+func (cs Cases[T]) Run(t *testing.T, v validators.Validator[T]) {
+	t.Helper()
+
+	for _, c := range cs {
+		name := c.Name
+		if name == "" {
+			name = fmt.Sprintf("%#v", c.Value)
+		}
+
+		t.Run(name, func(t *testing.T) {
+			t.Helper()
+
+			err := v.Validate(c.Value)
+			if d := diff(c.Want, err); d != "" {
+				t.Errorf("validating %#v (-want +got):\n%s", c.Value, d)
+			}
+		})
+	}
+}
+
+// diff returns a line per violation prefixed with "-" when it was expected
+// but missing and "+" when it was reported but unexpected. Matching
+// violations are listed unprefixed for context. An empty string means the
+// violations match.
+func diff(want []Violation, err error) string {
+	want = slices.Clone(want)
+	slices.SortFunc(want, compareViolations)
+
+	got := slices.Clone(validators.AsErrors(err))
+	slices.SortFunc(got, func(a, b *validators.Error) int {
+		return compareViolations(Violation{a.Path, a.Code}, Violation{b.Path, b.Code})
+	})
+
+	var (
+		b       strings.Builder
+		changed bool
+	)
+
+	for _, w := range want {
+		i := slices.IndexFunc(got, func(e *validators.Error) bool {
+			return e.Path == w.Path && e.Code == w.Code
+		})
+		if i < 0 {
+			fmt.Fprintf(&b, "- %s\n", w)
+			changed = true
+
+			continue
+		}
+
+		fmt.Fprintf(&b, "  %s\n", w)
+		got = slices.Delete(got, i, i+1)
+	}
+
+	for _, e := range got {
+		fmt.Fprintf(&b, "+ %s (%s)\n", Violation{e.Path, e.Code}, e.Text())
+		changed = true
+	}
+
+	if !changed {
+		return ""
+	}
+
+	return b.String()
+}
+
+func render(errs validators.Errors) string {
+	var b strings.Builder
+	for _, e := range errs {
+		fmt.Fprintf(&b, "  %s (%s)\n", Violation{e.Path, e.Code}, e.Text())
+	}
+
+	return b.String()
+}
+
+func compareViolations(a, b Violation) int {
+	if c := strings.Compare(a.Path, b.Path); c != 0 {
+		return c
+	}
+
+	return strings.Compare(a.Code, b.Code)
+}
@@ -0,0 +1,107 @@
+package validtest_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/bitcrshr/valid/validators"
+	"github.com/bitcrshr/valid/validtest"
+)
+
+// recorder captures reported failures instead of failing the test.
+type recorder struct {
+	testing.TB
+	errs []string
+}
+
+func (r *recorder) Helper() {}
+
+func (r *recorder) Errorf(format string, args ...any) {
+	r.errs = append(r.errs, fmt.Sprintf(format, args...))
+}
+
+type Address struct {
+	City string
+}
+
+type User struct {
+	Name    string
+	Tags    []string
+	Address Address
+}
+
+func userValidator() validators.Validator[User] {
+	return validators.MustStructValidator[User](validators.StructShape{
+		"Name": validators.NewStringValidator[string]().MinLen(2),
+		"Tags": validators.NewSliceValidator[[]string](
+			validators.NewStringValidator[string]().NotEmpty(),
+		),
+		"Address": validators.MustStructValidator[Address](validators.StructShape{
+			"City": validators.NewStringValidator[string]().NotEmpty(),
+		}),
+	})
+}
+
+func TestAssertValid(t *testing.T) {
+	v := userValidator()
+
+	r := &recorder{TB: t}
+	if !validtest.AssertValid(r, v, User{Name: "Ada", Address: Address{City: "London"}}) || len(r.errs) != 0 {
+		t.Errorf("expected valid user to pass, got %v", r.errs)
+	}
+
+	r = &recorder{TB: t}
+	if validtest.AssertValid(r, v, User{}) || len(r.errs) != 1 {
+		t.Errorf("expected invalid user to be reported once, got %v", r.errs)
+	}
+}
+
+func TestAssertInvalid(t *testing.T) {
+	v := userValidator()
+	bad := User{Name: "Ada", Tags: []string{"a", ""}, Address: Address{City: "London"}}
+
+	validtest.AssertInvalid(t, v, bad, "Tags[1]", "notEmpty")
+
+	r := &recorder{TB: t}
+	if validtest.AssertInvalid(r, v, bad, "Tags[0]", "notEmpty") || len(r.errs) != 1 {
+		t.Errorf("expected wrong path to be reported, got %v", r.errs)
+	}
+
+	r = &recorder{TB: t}
+	if validtest.AssertInvalid(r, v, User{Name: "Ada", Address: Address{City: "x"}}, "", "minLen") || len(r.errs) != 1 {
+		t.Errorf("expected passing value to be reported, got %v", r.errs)
+	}
+}
+
+func TestCases(t *testing.T) {
+	validtest.Cases[User]{
+		{
+			Name:  "valid",
+			Value: User{Name: "Ada", Tags: []string{"math"}, Address: Address{City: "London"}},
+		},
+		{
+			Name:  "everything wrong",
+			Value: User{Name: "A", Tags: []string{"", "ok", ""}},
+			Want: []validtest.Violation{
+				{Path: "Address.City", Code: "notEmpty"},
+				{Path: "Name", Code: "minLen"},
+				{Path: "Tags[0]", Code: "notEmpty"},
+				{Path: "Tags[2]", Code: "notEmpty"},
+			},
+		},
+	}.Run(t, userValidator())
+}
+
+func TestViolations(t *testing.T) {
+	err := userValidator().Validate(User{Name: "Ada", Tags: []string{""}})
+
+	got := fmt.Sprint(validtest.Violations(err))
+	want := "[Address.City: notEmpty Tags[0]: notEmpty]"
+	if got != want {
+		t.Errorf("expected violations %s, got %s", want, got)
+	}
+
+	if got := validtest.Violations(fmt.Errorf("boom")); len(got) != 1 || got[0].Code != validators.CodeCustom {
+		t.Errorf("expected foreign error to be reported as %s, got %v", validators.CodeCustom, got)
+	}
+}